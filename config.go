@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -35,6 +37,9 @@ func DefaultConfig() *Config {
 		},
 		Commands: CommandsConfig{
 			Commands: cmd.Commands{
+				Trigger: cmd.Trigger{
+					Cmd: cmd.Cmd{Triggers: []string{"?"}},
+				},
 				Settings: cmd.Settings{
 					Cmd: cmd.Cmd{Priviledge: gateway.AccessOwner},
 				},
@@ -56,28 +61,131 @@ func DefaultConfig() *Config {
 				Set: cmd.Set{
 					Cmd:           cmd.Cmd{Priviledge: gateway.AccessAdmin},
 					DefaultAccess: gateway.AccessWhitelist,
+					ConfirmConfig: cmd.ConfirmConfig{
+						ConfirmAbove:   5,
+						ConfirmTimeout: 30 * time.Second,
+					},
 				},
 				Kick: cmd.Kick{
 					Cmd:            cmd.Cmd{Priviledge: gateway.AccessOperator},
 					AccessProtect:  gateway.AccessWhitelist,
 					AccessOverride: gateway.AccessAdmin,
+					ConfirmConfig: cmd.ConfirmConfig{
+						ConfirmAbove:   5,
+						ConfirmTimeout: 30 * time.Second,
+					},
 				},
 				Ban: cmd.Ban{
 					Cmd:            cmd.Cmd{Priviledge: gateway.AccessOperator},
 					AccessProtect:  gateway.AccessWhitelist,
 					AccessOverride: gateway.AccessAdmin,
+					ConfirmConfig: cmd.ConfirmConfig{
+						ConfirmAbove:   5,
+						ConfirmTimeout: 30 * time.Second,
+					},
 				},
 				Unban: cmd.Unban{
 					Cmd:            cmd.Cmd{Priviledge: gateway.AccessOperator},
 					AccessProtect:  gateway.AccessBlacklist,
 					AccessOverride: gateway.AccessAdmin,
 				},
+				Undo: cmd.Undo{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessOperator},
+				},
+				Confirm: cmd.Confirm{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessOperator},
+				},
+				Lockdown: cmd.Lockdown{
+					Cmd:             cmd.Cmd{Priviledge: gateway.AccessOperator},
+					DefaultAccess:   gateway.AccessVoice,
+					DefaultDuration: 10 * time.Minute,
+				},
+				Gateway: cmd.Gateway{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessAdmin},
+				},
+				Join: cmd.Join{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessAdmin},
+				},
 				Ping: cmd.Ping{
 					Cmd: cmd.Cmd{Priviledge: gateway.AccessWhitelist},
 				},
 				Time: cmd.Time{
 					Format: "15:04:05 MST",
 				},
+				Seen: cmd.Seen{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessVoice},
+				},
+				Tell: cmd.Tell{
+					Cmd:   cmd.Cmd{Priviledge: gateway.AccessVoice},
+					Limit: 5,
+				},
+				Inbox: cmd.Inbox{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessVoice},
+				},
+				Clear: cmd.Clear{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessVoice},
+				},
+				Schedule: cmd.Schedule{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessAdmin},
+				},
+				Trivia: cmd.Trivia{
+					Cmd:           cmd.Cmd{Priviledge: gateway.AccessVoice},
+					Packs:         []string{"./trivia/*.json", "./trivia/*.toml"},
+					Questions:     10,
+					Timeout:       30 * time.Second,
+					Hints:         2,
+					MaxResults:    5,
+					AccessControl: gateway.AccessWhitelist,
+				},
+				Points: cmd.Points{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessVoice},
+				},
+				Top: cmd.Top{
+					Cmd:        cmd.Cmd{Priviledge: gateway.AccessVoice},
+					MaxResults: 5,
+				},
+				Give: cmd.Give{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessVoice},
+				},
+				Redeem: cmd.Redeem{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessDefault},
+					Rewards: map[string]*cmd.Reward{
+						"voice": &cmd.Reward{
+							Cost:     100,
+							Access:   gateway.AccessVoice,
+							Duration: 24 * time.Hour,
+						},
+					},
+				},
+				Highlight: cmd.Highlight{
+					Cmd:   cmd.Cmd{Priviledge: gateway.AccessVoice},
+					Limit: 10,
+				},
+				History: cmd.History{
+					Cmd:        cmd.Cmd{Priviledge: gateway.AccessVoice},
+					DefaultNum: 10,
+					MaxNum:     50,
+				},
+				Afk: cmd.Afk{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessVoice},
+				},
+				RemindMe: cmd.RemindMe{
+					Cmd:   cmd.Cmd{Priviledge: gateway.AccessVoice},
+					Limit: 5,
+				},
+				Remind: cmd.Remind{
+					Cmd:   cmd.Cmd{Priviledge: gateway.AccessWhitelist},
+					Limit: 5,
+				},
+				Reminders: cmd.Reminders{
+					Cmd: cmd.Cmd{Priviledge: gateway.AccessVoice},
+				},
+				Poll: cmd.Poll{
+					Cmd:            cmd.Cmd{Priviledge: gateway.AccessWhitelist},
+					Duration:       5 * time.Minute,
+					VoteAccess:     gateway.AccessVoice,
+					AccessOverride: gateway.AccessAdmin,
+				},
 			},
 			Alias: map[string]*cmd.Alias{
 				"whisper": &cmd.Alias{
@@ -85,37 +193,49 @@ func DefaultConfig() *Config {
 					Exe:            "sayprivate",
 					Arg:            []string{"%ARG1%", "<%USTR%> %RARG2..%"},
 					ArgExpected:    2,
+					ArgUsage:       "[username] [message...]",
+					Help:           "Whisper message to user",
 					WithPriviledge: gateway.AccessAdmin,
 				},
 				"pingme": &cmd.Alias{
 					Cmd:            cmd.Cmd{Priviledge: gateway.AccessVoice},
 					Exe:            "ping",
 					Arg:            []string{"%USTR%"},
+					Help:           "Print your ping",
 					WithPriviledge: gateway.AccessWhitelist,
 				},
 				"banlist": &cmd.Alias{
-					Exe: "list",
-					Arg: []string{gateway.AccessMin.String(), gateway.AccessBan.String()},
+					Exe:  "list",
+					Arg:  []string{gateway.AccessMin.String(), gateway.AccessBan.String()},
+					Help: "List banned users",
 				},
 				"unset": &cmd.Alias{
 					Exe:         "set",
 					Arg:         []string{"%ARG1%", gateway.AccessDefault.String()},
 					ArgExpected: 1,
+					ArgUsage:    "[username]",
+					Help:        "Reset access level for user",
 				},
 				"whitelist": &cmd.Alias{
 					Exe:         "set",
 					Arg:         []string{"%ARG1%", gateway.AccessWhitelist.String()},
 					ArgExpected: 1,
+					ArgUsage:    "[username]",
+					Help:        "Set access level for user to whitelist",
 				},
 				"ignore": &cmd.Alias{
 					Exe:         "set",
 					Arg:         []string{"%ARG1%", gateway.AccessIgnore.String()},
 					ArgExpected: 1,
+					ArgUsage:    "[username]",
+					Help:        "Set access level for user to ignore",
 				},
 				"blacklist": &cmd.Alias{
 					Exe:         "set",
 					Arg:         []string{"%ARG1%", gateway.AccessBlacklist.String()},
 					ArgExpected: 1,
+					ArgUsage:    "[username]",
+					Help:        "Set access level for user to blacklist",
 				},
 				"unwhitelist": &cmd.Alias{Exe: "unset"},
 				"unignore":    &cmd.Alias{Exe: "unset"},
@@ -130,11 +250,16 @@ func DefaultConfig() *Config {
 				"k":           &cmd.Alias{Exe: "capi" + gateway.Delimiter + "kick"},
 				"b":           &cmd.Alias{Exe: "capi" + gateway.Delimiter + "ban"},
 			},
+			Cooldown: goop.CooldownConfig{
+				BypassAccess: gateway.AccessAdmin,
+			},
 		},
 		Default: gateway.Config{
 			Commands: gateway.TriggerConfig{
-				Access:  gateway.AccessVoice,
-				Trigger: ".",
+				Access:          gateway.AccessVoice,
+				Trigger:         ".",
+				Address:         []string{"goop", "all"},
+				AddressOperator: []string{"ops"},
 			},
 		},
 		StdIO: stdio.Config{
@@ -201,23 +326,50 @@ func DefaultConfig() *Config {
 				},
 			},
 		},
+		Points: goop.PointsConfig{
+			PerMessage:    1,
+			Interval:      time.Minute,
+			MinLength:     3,
+			KarmaCooldown: time.Minute,
+		},
+		Highlight: goop.HighlightConfig{
+			Interval: time.Minute,
+			Idle:     5 * time.Minute,
+		},
+		History: goop.HistoryConfig{
+			Size: 50,
+		},
+		Away: goop.AwayConfig{
+			Interval:    time.Minute,
+			MaxMentions: 20,
+		},
 		Plugins: map[string]*PluginConfigWithDefault{},
 	}
 }
 
 // Config struct maps the layout of main configuration file
 type Config struct {
-	Hash     string
-	Config   string
-	Log      LogConfig
-	Commands CommandsConfig
-	Plugins  PluginsConfig
-	Default  gateway.Config
-	StdIO    stdio.Config
-	Capi     CapiConfigWithDefault
-	BNet     BNetConfigWithDefault
-	Discord  DiscordConfigWithDefault
-	Relay    RelayConfigWithDefault
+	Hash      string
+	Config    string
+	Log       LogConfig
+	Commands  CommandsConfig
+	Plugins   PluginsConfig
+	Default   gateway.Config
+	StdIO     stdio.Config
+	Capi      CapiConfigWithDefault
+	BNet      BNetConfigWithDefault
+	Discord   DiscordConfigWithDefault
+	Relay     RelayConfigWithDefault
+	Seen      goop.SeenConfig
+	Mail      goop.MailConfig
+	Schedule  goop.ScheduleConfig
+	Trivia    goop.TriviaConfig
+	Points    goop.PointsConfig
+	Highlight goop.HighlightConfig
+	History   goop.HistoryConfig
+	Away      goop.AwayConfig
+	Reminder  goop.ReminderConfig
+	Identity  goop.IdentityConfig
 }
 
 // LogConfig struct maps the layout of the Log configuration section
@@ -231,7 +383,8 @@ type LogConfig struct {
 // CommandsConfig struct maps the layout of the Commands configuration section
 type CommandsConfig struct {
 	cmd.Commands
-	Alias map[string]*cmd.Alias
+	Alias    map[string]*cmd.Alias
+	Cooldown goop.CooldownConfig
 }
 
 // PluginsConfig struct maps the layout of the Plugins configuration section
@@ -343,6 +496,117 @@ func (c *Config) Save(def *Config) error {
 	return toml.NewEncoder(file).Encode(m)
 }
 
+// flatDefaults returns the flat map of c with defaults applied to c and the relay pairs in rel
+func (c *Config) flatDefaults(rel map[string]*RelayToConfig) (map[string]interface{}, error) {
+	cp, err := c.Copy()
+	if err != nil {
+		return nil, err
+	}
+	if cp.Relay.To == nil {
+		cp.Relay.To = make(map[string]*RelayToConfig)
+	}
+	for to, r := range rel {
+		for from := range r.From {
+			cp.GetRelay(to, from)
+		}
+	}
+	if err := cp.MergeDefaults(); err != nil {
+		return nil, err
+	}
+	return cp.FlatMap(), nil
+}
+
+// Reload applies the changes between user configurations prev and next to c
+// Returns the changed keys, sorted
+func (c *Config) Reload(prev, next *Config) ([]string, error) {
+	pm, err := prev.flatDefaults(c.Relay.To)
+	if err != nil {
+		return nil, err
+	}
+	nm, err := next.flatDefaults(c.Relay.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var conf Config
+	if _, err := Merge(&conf, c.Map(), &MergeOptions{Overwrite: true}); err != nil {
+		return nil, err
+	}
+
+	var keys = make([]string, 0, len(nm))
+	for k := range nm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// Slices are replaced as a whole, skip their elements
+	var slices = []string{}
+	var inSlice = func(key string) bool {
+		for _, s := range slices {
+			if strings.HasPrefix(key, s+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	var changed = []string{}
+	for _, k := range keys {
+		if k == "Config" || inSlice(k) {
+			continue
+		}
+
+		var v = nm[k]
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Map:
+			// Entries are compared individually
+			continue
+		case reflect.Slice:
+			slices = append(slices, k)
+		}
+
+		if o, ok := pm[k]; ok && reflect.DeepEqual(o, v) {
+			continue
+		}
+		if err := Set(&conf, k, v); err != nil {
+			return nil, err
+		}
+		changed = append(changed, k)
+	}
+
+	// Unset the topmost key that disappeared
+	var removed = map[string]bool{}
+	for k := range pm {
+		if _, ok := nm[k]; ok || inSlice(k) {
+			continue
+		}
+		for {
+			var p, _ = ParentKey(k)
+			if f, _ := Find(next, p); p == "" || f != nil {
+				break
+			}
+			k = p
+		}
+		removed[k] = true
+	}
+	for k := range removed {
+		if err := Unset(&conf, k); err != nil && err != ErrUnknownKey {
+			return nil, err
+		}
+		changed = append(changed, k)
+	}
+
+	if err := conf.MergeDefaults(); err != nil {
+		return nil, err
+	}
+	if _, err := Merge(c, conf, &MergeOptions{Overwrite: true, Delete: true}); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(changed)
+	return changed, nil
+}
+
 // Copy config
 func (c *Config) Copy() (*Config, error) {
 	var conf Config
@@ -352,6 +616,61 @@ func (c *Config) Copy() (*Config, error) {
 	return &conf, nil
 }
 
+// GetCooldown config for commands
+func (c *Config) GetCooldown() *goop.CooldownConfig {
+	return &c.Commands.Cooldown
+}
+
+// GetSeen user activity
+func (c *Config) GetSeen() *goop.SeenConfig {
+	return &c.Seen
+}
+
+// GetMail undelivered messages
+func (c *Config) GetMail() *goop.MailConfig {
+	return &c.Mail
+}
+
+// GetSchedule entries
+func (c *Config) GetSchedule() *goop.ScheduleConfig {
+	return &c.Schedule
+}
+
+// GetTrivia scoreboard
+func (c *Config) GetTrivia() *goop.TriviaConfig {
+	return &c.Trivia
+}
+
+// GetPoints ledger
+func (c *Config) GetPoints() *goop.PointsConfig {
+	return &c.Points
+}
+
+// GetHighlight subscriptions
+func (c *Config) GetHighlight() *goop.HighlightConfig {
+	return &c.Highlight
+}
+
+// GetHistory buffer
+func (c *Config) GetHistory() *goop.HistoryConfig {
+	return &c.History
+}
+
+// GetAway users
+func (c *Config) GetAway() *goop.AwayConfig {
+	return &c.Away
+}
+
+// GetReminder pending reminders
+func (c *Config) GetReminder() *goop.ReminderConfig {
+	return &c.Reminder
+}
+
+// GetIdentity links
+func (c *Config) GetIdentity() *goop.IdentityConfig {
+	return &c.Identity
+}
+
 // GetRelay config between to and from
 func (c *Config) GetRelay(to, from string) *goop.RelayConfig {
 	if c.Relay.To[to] == nil {
@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	bnetc "github.com/nielsAD/gowarcraft3/network/bnet"
 
 	"github.com/nielsAD/goop/gateway"
 	"github.com/nielsAD/goop/gateway/bnet"
 	"github.com/nielsAD/goop/gateway/discord"
+	"github.com/nielsAD/goop/goop"
 )
 
 func TestMergeDefaults(t *testing.T) {
@@ -133,6 +135,30 @@ func TestMap(t *testing.T) {
 	}
 }
 
+func TestMapTime(t *testing.T) {
+	var ts = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var cfg = Config{
+		Seen: goop.SeenConfig{
+			Users: map[string]map[string]*goop.SeenUser{
+				"gw": {"uid": &goop.SeenUser{Name: "foo", LastSeen: ts}},
+			},
+		},
+	}
+
+	var m = cfg.Map()
+	if v := m["Seen"].(mi)["Users"].(mi)["gw"].(mi)["uid"].(mi)["LastSeen"]; v != ts {
+		t.Fatal("Expected time.Time value, got", v)
+	}
+
+	var cp Config
+	if _, err := Merge(&cp, m, &MergeOptions{Overwrite: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !cp.Seen.Users["gw"]["uid"].LastSeen.Equal(ts) {
+		t.Fatal("LastSeen different from expected value")
+	}
+}
+
 func TestFlatMap(t *testing.T) {
 	var cfg = Config{
 		BNet: BNetConfigWithDefault{
@@ -355,6 +381,62 @@ func TestSetString(t *testing.T) {
 	if !reflect.DeepEqual(cfg.BNet.Default.CDKeys, []string{"111", "xxx", "555", "777", "999"}) {
 		t.Fatal("CDKeys(5) mismatch")
 	}
+
+	if err := cfg.SetString("bnet/gateways/new/username", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BNet.Gateways["new"] == nil || cfg.BNet.Gateways["new"].Username != "bar" {
+		t.Fatal("Expected gateways[new] to be created")
+	}
+	if err := cfg.SetString("bnet/default/foo/bar", "baz"); err != ErrUnknownKey {
+		t.Fatal("Expected ErrUnknownKey, got", err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	var prev = DefaultConfig()
+	prev.BNet.Gateways = map[string]*bnet.Config{
+		"foo": &bnet.Config{},
+		"bar": &bnet.Config{},
+	}
+	prev.BNet.Default.AccessUser = map[string]gateway.AccessLevel{"niels": gateway.AccessAdmin}
+
+	conf, err := prev.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var foo = conf.BNet.Gateways["foo"]
+	foo.AccessUser["bob"] = gateway.AccessVoice
+
+	var next = DefaultConfig()
+	next.BNet.Gateways = map[string]*bnet.Config{
+		"foo": &bnet.Config{},
+		"baz": &bnet.Config{},
+	}
+	next.BNet.Default.HomeChannel = "goop"
+	next.BNet.Default.AccessUser = map[string]gateway.AccessLevel{"niels": gateway.AccessAdmin}
+	next.Commands.Trigger.Priviledge = gateway.AccessOwner
+
+	keys, err := conf.Reload(prev, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) == 0 {
+		t.Fatal("Expected changed keys")
+	}
+
+	if conf.BNet.Gateways["foo"] != foo || foo.HomeChannel != "goop" || foo.AccessUser["bob"] != gateway.AccessVoice {
+		t.Fatal("Expected foo to be updated in place")
+	}
+	if conf.BNet.Gateways["bar"] != nil {
+		t.Fatal("Expected bar to be removed")
+	}
+	if conf.BNet.Gateways["baz"] == nil || conf.BNet.Gateways["baz"].HomeChannel != "goop" {
+		t.Fatal("Expected baz to be added")
+	}
+	if conf.Commands.Trigger.Priviledge != gateway.AccessOwner {
+		t.Fatal("Expected trigger priviledge to be owner")
+	}
 }
 
 func benchConfig(n int) *Config {
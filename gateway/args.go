@@ -0,0 +1,467 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors
+var (
+	ErrInvalidSpec = errors.New("gw: Invalid argument spec")
+	ErrArgMissing  = errors.New("gw: Missing argument")
+	ErrArgInvalid  = errors.New("gw: Invalid argument")
+	ErrArgUnknown  = errors.New("gw: Unknown option")
+)
+
+// ArgType enum
+type ArgType int32
+
+// ArgTypes
+const (
+	ArgString      ArgType = iota // Single word (or quoted string)
+	ArgUser                       // User pattern, resolved with FindUser
+	ArgChannelUser                // User pattern, resolved with FindUserInChannel
+	ArgAccess                     // AccessLevel
+	ArgDuration                   // time.Duration, or number of seconds
+	ArgInt                        // Integer
+	ArgBool                       // Boolean
+	ArgRest                       // Rest of line
+)
+
+var argTypeNames = []string{"string", "user", "chanuser", "access", "duration", "int", "bool", "rest"}
+
+func (t ArgType) String() string {
+	if t >= 0 && int(t) < len(argTypeNames) {
+		return argTypeNames[t]
+	}
+	return fmt.Sprintf("ArgType(%d)", int32(t))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t ArgType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *ArgType) UnmarshalText(text []byte) error {
+	var s = strings.ToLower(string(text))
+	for i, n := range argTypeNames {
+		if s == n {
+			*t = ArgType(i)
+			return nil
+		}
+	}
+	return ErrInvalidSpec
+}
+
+// Arg declares a single command argument or --flag
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Optional bool
+	Default  string
+}
+
+func (a *Arg) spec(flag bool) string {
+	var res = a.Name
+	if flag {
+		res = "--" + res
+		if a.Type != ArgBool {
+			res += ":" + a.Type.String()
+		}
+	} else if a.Type != ArgString {
+		res += ":" + a.Type.String()
+	}
+	if a.Default != "" {
+		res += "=" + a.Default
+	} else if a.Optional && !flag {
+		res += "?"
+	}
+	return res
+}
+
+func (a *Arg) usage(flag bool) string {
+	var res = a.Name
+	if flag {
+		res = "--" + res
+		if a.Type != ArgBool {
+			res += "=<" + a.Type.String() + ">"
+		}
+		return "[" + res + "]"
+	}
+	if a.Type == ArgRest {
+		res += "..."
+	}
+	if a.Default != "" {
+		res += "=" + a.Default
+	} else if a.Optional {
+		res += "?"
+	}
+	return "[" + res + "]"
+}
+
+// ArgSpec declares the arguments of a command
+//
+// Spec strings contain whitespace separated arguments in the form of `name[:type][?|=default]`,
+// where flags are prefixed with `--` (i.e. `user:user duration:duration=1h reason:rest? --silent`)
+type ArgSpec struct {
+	Args  []Arg
+	Flags []Arg
+}
+
+// ParseArgSpec parses spec string s
+func ParseArgSpec(s string) (*ArgSpec, error) {
+	var res ArgSpec
+	for _, f := range strings.Fields(s) {
+		var a Arg
+		var flag = strings.HasPrefix(f, "--")
+		if flag {
+			f = f[2:]
+			a.Type = ArgBool
+			a.Optional = true
+		}
+
+		if i := strings.IndexByte(f, '='); i >= 0 {
+			a.Default = f[i+1:]
+			a.Optional = true
+			f = f[:i]
+		} else if strings.HasSuffix(f, "?") {
+			a.Optional = true
+			f = f[:len(f)-1]
+		}
+
+		if i := strings.IndexByte(f, ':'); i >= 0 {
+			if err := a.Type.UnmarshalText([]byte(f[i+1:])); err != nil {
+				return nil, err
+			}
+			f = f[:i]
+		}
+
+		a.Name = strings.ToLower(f)
+		if a.Name == "" || (flag && a.Type == ArgRest) {
+			return nil, ErrInvalidSpec
+		}
+
+		if flag {
+			res.Flags = append(res.Flags, a)
+			continue
+		}
+
+		if n := len(res.Args); n > 0 && (res.Args[n-1].Type == ArgRest || (res.Args[n-1].Optional && !a.Optional)) {
+			// Nothing can follow rest-of-line, required arguments cannot follow optional arguments
+			return nil, ErrInvalidSpec
+		}
+		res.Args = append(res.Args, a)
+	}
+	return &res, nil
+}
+
+// MustParseArgSpec is like ParseArgSpec but panics if spec cannot be parsed
+func MustParseArgSpec(s string) *ArgSpec {
+	res, err := ParseArgSpec(s)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func (s *ArgSpec) String() string {
+	var l = make([]string, 0, len(s.Args)+len(s.Flags))
+	for i := range s.Args {
+		l = append(l, s.Args[i].spec(false))
+	}
+	for i := range s.Flags {
+		l = append(l, s.Flags[i].spec(true))
+	}
+	return strings.Join(l, " ")
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s ArgSpec) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (s *ArgSpec) UnmarshalText(text []byte) error {
+	res, err := ParseArgSpec(string(text))
+	if err != nil {
+		return err
+	}
+	*s = *res
+	return nil
+}
+
+// Usage string (i.e. `[username] [access?] [--silent]`)
+func (s *ArgSpec) Usage() string {
+	var l = make([]string, 0, len(s.Args)+len(s.Flags))
+	for i := range s.Args {
+		l = append(l, s.Args[i].usage(false))
+	}
+	for i := range s.Flags {
+		l = append(l, s.Flags[i].usage(true))
+	}
+	return strings.Join(l, " ")
+}
+
+// Required number of arguments
+func (s *ArgSpec) Required() int {
+	var n = 0
+	for _, a := range s.Args {
+		if !a.Optional {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *ArgSpec) flag(name string) *Arg {
+	for i := range s.Flags {
+		if strings.EqualFold(s.Flags[i].Name, name) {
+			return &s.Flags[i]
+		}
+	}
+	return nil
+}
+
+// ArgError is returned by ArgSpec.Parse for invalid input
+type ArgError struct {
+	Spec  *ArgSpec
+	Arg   *Arg
+	Value string
+	Err   error
+}
+
+func (e *ArgError) Error() string {
+	switch e.Err {
+	case ErrArgMissing:
+		var n = e.Spec.Required()
+		var s = "s"
+		if n == 1 {
+			s = ""
+		}
+		return fmt.Sprintf("Expected %d argument%s: %s", n, s, e.Spec.Usage())
+	case ErrArgUnknown:
+		return fmt.Sprintf("Unknown option `%s`, expected: %s", e.Value, e.Spec.Usage())
+	default:
+		return fmt.Sprintf("Invalid %s `%s` for [%s], expected: %s", e.Arg.Type, e.Value, e.Arg.Name, e.Spec.Usage())
+	}
+}
+
+// Unwrap returns the underlying error
+func (e *ArgError) Unwrap() error {
+	return e.Err
+}
+
+// Args stores parsed argument values
+type Args struct {
+	str map[string]string
+	val map[string]interface{}
+	pos []string
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	var d time.Duration
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		d = time.Duration(n) * time.Second
+	} else if d, err = time.ParseDuration(s); err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, ErrArgInvalid
+	}
+	return d, nil
+}
+
+func (a *Args) set(arg *Arg, s string, gw Gateway) error {
+	var v interface{}
+	var err error
+
+	switch arg.Type {
+	case ArgUser:
+		if gw != nil {
+			v = FindUser(gw, s)
+		}
+	case ArgChannelUser:
+		if gw != nil {
+			v = FindUserInChannel(gw, s)
+		}
+	case ArgAccess:
+		var l AccessLevel
+		err = l.UnmarshalText([]byte(s))
+		v = l
+	case ArgDuration:
+		v, err = parseDuration(s)
+	case ArgInt:
+		// Base 10 only, so leading zeroes do not change the value (i.e. 010 is not octal)
+		v, err = strconv.ParseInt(s, 10, 64)
+	case ArgBool:
+		v, err = strconv.ParseBool(s)
+	default:
+		v = s
+	}
+
+	if err != nil {
+		return err
+	}
+
+	a.str[arg.Name] = s
+	a.val[arg.Name] = v
+	return nil
+}
+
+// Parse arguments of t according to spec, resolving user arguments on gw
+// The error is also stored in t, see Trigger.UsageError
+func (s *ArgSpec) Parse(t *Trigger, gw Gateway) (*Args, error) {
+	res, err := s.parse(t, gw)
+	if err != nil {
+		t.usageErr = err
+	}
+	return res, err
+}
+
+func (s *ArgSpec) parse(t *Trigger, gw Gateway) (*Args, error) {
+	var res = Args{
+		str: make(map[string]string),
+		val: make(map[string]interface{}),
+	}
+
+	var pos = 0
+	var flags = len(s.Flags) > 0
+	for i := 0; i < len(t.Arg); i++ {
+		var raw = t.Arg[i]
+		if i < len(t.Raw) {
+			raw = t.Raw[i]
+		}
+
+		if flags && strings.HasPrefix(raw, "--") {
+			if strings.TrimSpace(raw) == "--" {
+				flags = false
+				continue
+			}
+
+			var name, val = t.Arg[i][2:], ""
+			var hasVal = false
+			if j := strings.IndexByte(name, '='); j >= 0 {
+				name, val, hasVal = name[:j], name[j+1:], true
+			}
+
+			var f = s.flag(name)
+			if f == nil {
+				return nil, &ArgError{Spec: s, Value: t.Arg[i], Err: ErrArgUnknown}
+			}
+
+			if !hasVal {
+				if f.Type == ArgBool {
+					val = "true"
+				} else if i+1 < len(t.Arg) {
+					i++
+					val = t.Arg[i]
+				} else {
+					return nil, &ArgError{Spec: s, Arg: f, Err: ErrArgMissing}
+				}
+			}
+
+			if err := res.set(f, val, gw); err != nil {
+				return nil, &ArgError{Spec: s, Arg: f, Value: val, Err: ErrArgInvalid}
+			}
+			continue
+		}
+
+		if pos >= len(s.Args) {
+			// Ignore superfluous arguments
+			continue
+		}
+
+		var a = &s.Args[pos]
+		pos++
+
+		var val = t.Arg[i]
+		if a.Type == ArgRest {
+			val = strings.Join(t.Raw[i:], "")
+			i = len(t.Arg)
+		}
+		if err := res.set(a, val, gw); err != nil {
+			return nil, &ArgError{Spec: s, Arg: a, Value: val, Err: ErrArgInvalid}
+		}
+		res.pos = append(res.pos, val)
+	}
+
+	for ; pos < len(s.Args); pos++ {
+		var a = &s.Args[pos]
+		if a.Default == "" {
+			if !a.Optional {
+				return nil, &ArgError{Spec: s, Arg: a, Err: ErrArgMissing}
+			}
+			continue
+		}
+		if err := res.set(a, a.Default, gw); err != nil {
+			return nil, &ArgError{Spec: s, Arg: a, Value: a.Default, Err: ErrArgInvalid}
+		}
+		res.pos = append(res.pos, a.Default)
+	}
+
+	for i := range s.Flags {
+		var f = &s.Flags[i]
+		if _, ok := res.str[f.Name]; ok || f.Default == "" {
+			continue
+		}
+		if err := res.set(f, f.Default, gw); err != nil {
+			return nil, &ArgError{Spec: s, Arg: f, Value: f.Default, Err: ErrArgInvalid}
+		}
+	}
+
+	return &res, nil
+}
+
+// Has returns true if argument name was passed (or has a default value)
+func (a *Args) Has(name string) bool {
+	_, ok := a.str[strings.ToLower(name)]
+	return ok
+}
+
+// Get argument name as string
+func (a *Args) Get(name string) string {
+	return a.str[strings.ToLower(name)]
+}
+
+// Positional returns the values of all positional arguments (including defaults)
+func (a *Args) Positional() []string {
+	return a.pos
+}
+
+// Users returns the users that match argument name
+func (a *Args) Users(name string) []*User {
+	v, _ := a.val[strings.ToLower(name)].([]*User)
+	return v
+}
+
+// Access returns argument name as AccessLevel
+func (a *Args) Access(name string) AccessLevel {
+	v, _ := a.val[strings.ToLower(name)].(AccessLevel)
+	return v
+}
+
+// Duration returns argument name as time.Duration
+func (a *Args) Duration(name string) time.Duration {
+	v, _ := a.val[strings.ToLower(name)].(time.Duration)
+	return v
+}
+
+// Int returns argument name as integer
+func (a *Args) Int(name string) int64 {
+	v, _ := a.val[strings.ToLower(name)].(int64)
+	return v
+}
+
+// Bool returns argument name as boolean
+func (a *Args) Bool(name string) bool {
+	v, _ := a.val[strings.ToLower(name)].(bool)
+	return v
+}
@@ -0,0 +1,71 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package gateway_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+)
+
+func TestArgSpec(t *testing.T) {
+	var spec = gateway.MustParseArgSpec("access:access num:int=3 dur:duration? msg:rest? --force --reason:string")
+	if u := spec.Usage(); u != "[access] [num=3] [dur?] [msg...?] [--force] [--reason=<string>]" {
+		t.Fatalf("Usage: %s", u)
+	}
+	if s := spec.String(); s != "access:access num:int=3 dur:duration? msg:rest? --force --reason:string" {
+		t.Fatalf("String: %s", s)
+	}
+
+	_, err := spec.Parse(gateway.ExtractTrigger(`cmd admin --force --reason`), nil)
+	if err == nil || !errors.Is(err, gateway.ErrArgMissing) {
+		t.Fatal("Expected missing flag value", err)
+	}
+
+	args, err := spec.Parse(gateway.ExtractTrigger(`cmd admin+1 --force 5 --reason=spam 90 hello  "world" --x`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args.Access("access") != gateway.AccessAdmin+1 || args.Int("num") != 5 || args.Duration("dur") != 90*time.Second {
+		t.Fatal("Unexpected values", args.Positional())
+	}
+	if !args.Bool("force") || args.Get("reason") != "spam" || args.Get("msg") != `hello  "world" --x` {
+		t.Fatal("Unexpected flags", args.Get("reason"), args.Get("msg"))
+	}
+
+	args, err = spec.Parse(gateway.ExtractTrigger(`cmd voice`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args.Int("num") != 3 || args.Has("dur") || args.Bool("force") || len(args.Positional()) != 2 {
+		t.Fatal("Unexpected defaults", args.Positional())
+	}
+
+	if _, err := spec.Parse(gateway.ExtractTrigger(`cmd`), nil); err == nil || err.Error() != "Expected 1 argument: "+spec.Usage() {
+		t.Fatal("Expected missing argument", err)
+	}
+	if _, err := spec.Parse(gateway.ExtractTrigger(`cmd voice five`), nil); !errors.Is(err, gateway.ErrArgInvalid) {
+		t.Fatal("Expected invalid argument", err)
+	}
+	for _, in := range []string{`cmd voice 0x10`, `cmd voice 1 -5`, `cmd voice 1 -1h`} {
+		if _, err := spec.Parse(gateway.ExtractTrigger(in), nil); !errors.Is(err, gateway.ErrArgInvalid) {
+			t.Fatal("Expected invalid argument", in, err)
+		}
+	}
+	if args, err := spec.Parse(gateway.ExtractTrigger(`cmd voice 010`), nil); err != nil || args.Int("num") != 10 {
+		t.Fatal("Expected decimal number", err)
+	}
+	if _, err := spec.Parse(gateway.ExtractTrigger(`cmd voice --unknown`), nil); !errors.Is(err, gateway.ErrArgUnknown) {
+		t.Fatal("Expected unknown option", err)
+	}
+
+	for _, s := range []string{"a? b", "a:rest b", "--a:rest", "a:foo", ":int"} {
+		if _, err := gateway.ParseArgSpec(s); err == nil {
+			t.Fatalf("Expected invalid spec for %s", s)
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
@@ -207,6 +206,14 @@ func (b *Gateway) SayPrivate(uid string, s string) error {
 	return b.say(fmt.Sprintf("/w %s %s", uid, s))
 }
 
+// JoinChannel switches to channel name
+func (b *Gateway) JoinChannel(name string) error {
+	if b.Channel() == nil {
+		return gateway.ErrNoChannel
+	}
+	return b.say(fmt.Sprintf("/join %s", name))
+}
+
 // Kick user from channel
 func (b *Gateway) Kick(uid string) error {
 	if !b.Operator() {
@@ -394,20 +401,7 @@ func (b *Gateway) FindTrigger(s string) *gateway.Trigger {
 	if t := b.GatewayConfig.FindTrigger(s); t != nil {
 		return t
 	}
-
-	idx := strings.IndexAny(s, ",:")
-	if idx <= 0 || idx+2 >= len(s) || s[idx+1] != ' ' {
-		return nil
-	}
-
-	pat := s[:idx]
-	if !strings.EqualFold(pat, "goop") || strings.EqualFold(pat, "all") || (strings.EqualFold(pat, "ops") && b.Operator()) {
-		if m, _ := filepath.Match(pat, b.UniqueName); !m {
-			return nil
-		}
-	}
-
-	return gateway.ExtractTrigger(s[idx+2:])
+	return b.GatewayConfig.FindAddressedTrigger(s, b.UniqueName, b.Operator())
 }
 
 func (b *Gateway) onChat(ev *network.Event) {
@@ -8,7 +8,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
@@ -433,20 +432,7 @@ func (b *Gateway) FindTrigger(s string) *gateway.Trigger {
 	if t := b.GatewayConfig.FindTrigger(s); t != nil {
 		return t
 	}
-
-	idx := strings.IndexAny(s, ",:")
-	if idx <= 0 || idx+2 >= len(s) || s[idx+1] != ' ' {
-		return nil
-	}
-
-	pat := s[:idx]
-	if !strings.EqualFold(pat, "goop") || strings.EqualFold(pat, "all") || (strings.EqualFold(pat, "ops") && b.Operator()) {
-		if m, _ := filepath.Match(pat, b.name); !m || len(b.name) == 0 {
-			return nil
-		}
-	}
-
-	return gateway.ExtractTrigger(s[idx+2:])
+	return b.GatewayConfig.FindAddressedTrigger(s, b.name, b.Operator())
 }
 
 var banPat = regexp.MustCompile(`^([^ ]+) was banned by ([^ ]+).*\.$`)
@@ -27,6 +27,9 @@ type ChannelConfig struct {
 	OnlineListID   string
 	BufSize        uint8
 	RelayJoins     RelayJoinMode
+	BanRole        string
+	BanTimeout     time.Duration
+	SyncRoles      RoleSyncMode
 	AccessMentions gateway.AccessLevel
 	AccessTalk     gateway.AccessLevel
 	AccessRole     map[string]gateway.AccessLevel
@@ -49,6 +52,12 @@ type Channel struct {
 	ochan  chan struct{}
 	online []online
 
+	pmut  sync.Mutex
+	polls map[string]func(uid string, option int) bool
+
+	// Guards AccessUser
+	amut sync.Mutex
+
 	// Set once before Run(), read-only after that
 	*ChannelConfig
 }
@@ -153,16 +162,25 @@ func (c *Channel) User(uid string) (*gateway.User, error) {
 		}
 	}
 
+	c.amut.Lock()
 	if access := c.AccessUser[member.User.ID]; access != gateway.AccessDefault {
 		res.Access = access
 	}
+	c.amut.Unlock()
 
 	return &res, nil
 }
 
 // Users with non-default access level
 func (c *Channel) Users() map[string]gateway.AccessLevel {
-	return c.AccessUser
+	c.amut.Lock()
+	defer c.amut.Unlock()
+
+	var res = make(map[string]gateway.AccessLevel, len(c.AccessUser))
+	for k, v := range c.AccessUser {
+		res[k] = v
+	}
+	return res
 }
 
 // SetUserAccess overrides accesslevel for a specific user
@@ -171,6 +189,7 @@ func (c *Channel) SetUserAccess(uid string, a gateway.AccessLevel) (*gateway.Acc
 		return nil, err
 	}
 
+	c.amut.Lock()
 	var o = c.AccessUser[uid]
 	if a != gateway.AccessDefault {
 		if c.AccessUser == nil {
@@ -181,9 +200,18 @@ func (c *Channel) SetUserAccess(uid string, a gateway.AccessLevel) (*gateway.Acc
 	} else {
 		delete(c.AccessUser, uid)
 	}
+	c.amut.Unlock()
 
 	c.Fire(&gateway.ConfigUpdate{})
 
+	if c.SyncRoles&RoleSyncSet != 0 {
+		// Only touch the roles of the previous level, leave unrelated roles alone
+		var stale = func(r gateway.AccessLevel) bool { return r == o }
+		if err := c.syncRoles(uid, a, stale, true); err != nil {
+			c.Fire(&network.AsyncError{Src: "SetUserAccess[syncRoles]", Err: err})
+		}
+	}
+
 	if p, err := c.session.State.Presence(c.guildID, uid); err == nil && p.Status != discordgo.StatusOffline {
 		if u, err := c.User(uid); err == nil && u != nil {
 			c.Fire(u)
@@ -234,19 +262,185 @@ func (c *Channel) SayPrivate(uid string, s string) error {
 	return sayPrivate(c.session, uid, s)
 }
 
+func (c *Channel) member(uid string) (string, error) {
+	uid = strings.TrimSuffix(strings.TrimPrefix(uid, "<@"), ">")
+	if err := validateUID(uid); err != nil {
+		return "", err
+	}
+	if c.guildID == "" {
+		return "", gateway.ErrNoChannel
+	}
+	return uid, nil
+}
+
+func (c *Channel) banRole() (string, error) {
+	roles, err := c.session.GuildRoles(c.guildID)
+	if err != nil {
+		return "", restError(err)
+	}
+	for _, r := range roles {
+		if strings.EqualFold(r.Name, c.BanRole) {
+			return r.ID, nil
+		}
+	}
+	return "", ErrUnknownRole
+}
+
+// RoleDrift between AccessUser and the AccessRole roles of a guild member
+type RoleDrift struct {
+	UserID  string
+	Access  gateway.AccessLevel
+	Missing []string
+	Extra   []string
+}
+
+func (r *RoleDrift) Error() string {
+	return fmt.Sprintf("gw-discord: Role drift for %s <%s> (missing: %v, extra: %v)", r.UserID, r.Access, r.Missing, r.Extra)
+}
+
+// roleDrift compares the roles of uid with the AccessRole roles that match access level a
+// Roles are only considered extra if stale returns true for their access level
+func (c *Channel) roleDrift(uid string, a gateway.AccessLevel, stale func(gateway.AccessLevel) bool) (*RoleDrift, map[string]string, error) {
+	if len(c.AccessRole) == 0 {
+		return nil, nil, nil
+	}
+
+	member, err := c.session.State.Member(c.guildID, uid)
+	if err != nil {
+		if member, err = c.session.GuildMember(c.guildID, uid); err != nil {
+			// Nothing to sync if user is not a member
+			if err = restError(err); err == gateway.ErrNoUser {
+				err = nil
+			}
+			return nil, nil, err
+		}
+	}
+
+	roles, err := c.session.GuildRoles(c.guildID)
+	if err != nil {
+		return nil, nil, restError(err)
+	}
+
+	var has = make(map[string]bool, len(member.Roles))
+	for _, rid := range member.Roles {
+		has[rid] = true
+	}
+
+	var ids = make(map[string]string)
+	var res = RoleDrift{UserID: uid, Access: a}
+	for _, r := range roles {
+		access, ok := c.AccessRole[strings.ToLower(r.Name)]
+		if !ok {
+			continue
+		}
+
+		ids[r.Name] = r.ID
+		if access == a && a != gateway.AccessDefault {
+			if !has[r.ID] {
+				res.Missing = append(res.Missing, r.Name)
+			}
+		} else if has[r.ID] && access != a && stale(access) {
+			res.Extra = append(res.Extra, r.Name)
+		}
+	}
+
+	if len(res.Missing) == 0 && len(res.Extra) == 0 {
+		return nil, nil, nil
+	}
+
+	return &res, ids, nil
+}
+
+// syncRoles adds the AccessRole roles that match access level a, and removes the stale ones
+// Returns a RoleDrift error instead of updating roles if fix is false
+func (c *Channel) syncRoles(uid string, a gateway.AccessLevel, stale func(gateway.AccessLevel) bool, fix bool) error {
+	drift, ids, err := c.roleDrift(uid, a, stale)
+	if err != nil || drift == nil {
+		return err
+	}
+	if !fix {
+		return drift
+	}
+
+	for _, r := range drift.Missing {
+		if err := c.session.GuildMemberRoleAdd(c.guildID, uid, ids[r]); err != nil {
+			return restError(err)
+		}
+	}
+	for _, r := range drift.Extra {
+		if err := c.session.GuildMemberRoleRemove(c.guildID, uid, ids[r]); err != nil {
+			return restError(err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileRoles reports or fixes role drift for every user in AccessUser
+func (c *Channel) reconcileRoles() {
+	var fix = c.SyncRoles&RoleSyncFix != 0
+
+	// Snapshot, SetUserAccess may be called concurrently
+	var users = c.Users()
+	for uid, a := range users {
+		// Previous level is unknown, only roles that grant more than a are stale
+		var stale = func(r gateway.AccessLevel) bool { return r > a }
+		if err := c.syncRoles(uid, a, stale, fix); err != nil {
+			c.Fire(&network.AsyncError{Src: "reconcileRoles", Err: err})
+		}
+	}
+}
+
 // Kick user from channel
 func (c *Channel) Kick(uid string) error {
-	return gateway.ErrNotImplemented
+	uid, err := c.member(uid)
+	if err != nil {
+		return err
+	}
+	return restError(c.session.GuildMemberDelete(c.guildID, uid))
 }
 
-// Ban user from channel
+// Ban user from channel, or assign BanRole / BanTimeout if configured
 func (c *Channel) Ban(uid string) error {
-	return gateway.ErrNotImplemented
+	uid, err := c.member(uid)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case c.BanRole != "":
+		rid, err := c.banRole()
+		if err != nil {
+			return err
+		}
+		return restError(c.session.GuildMemberRoleAdd(c.guildID, uid, rid))
+	case c.BanTimeout > 0:
+		var until = time.Now().Add(c.BanTimeout)
+		return restError(c.session.GuildMemberTimeout(c.guildID, uid, &until))
+	default:
+		return restError(c.session.GuildBanCreate(c.guildID, uid, 0))
+	}
 }
 
-// Unban user from channel
+// Unban user from channel, or revoke BanRole / BanTimeout if configured
 func (c *Channel) Unban(uid string) error {
-	return gateway.ErrNotImplemented
+	uid, err := c.member(uid)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case c.BanRole != "":
+		rid, err := c.banRole()
+		if err != nil {
+			return err
+		}
+		return restError(c.session.GuildMemberRoleRemove(c.guildID, uid, rid))
+	case c.BanTimeout > 0:
+		return restError(c.session.GuildMemberTimeout(c.guildID, uid, nil))
+	default:
+		return restError(c.session.GuildBanDelete(c.guildID, uid))
+	}
 }
 
 // Ping user to calculate RTT in milliseconds
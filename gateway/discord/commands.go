@@ -0,0 +1,237 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package discord
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Discord API limits for application commands
+const (
+	maxCommands    = 100
+	maxDescription = 100
+)
+
+// Deferred interaction responses are removed if a command does not respond in time
+const interactionTimeout = 10 * time.Second
+
+var cmdNamePat = regexp.MustCompile(`^[-_\p{Ll}\p{N}]{1,32}$`)
+
+// SetCommands updates the list of slash commands (name => description)
+// Registered with every guild that contains a configured channel on (re)connect
+func (d *Gateway) SetCommands(cmds map[string]string) {
+	d.cmdmut.Lock()
+	d.commands = cmds
+	d.cmdmut.Unlock()
+}
+
+func (d *Gateway) applicationCommands() ([]*discordgo.ApplicationCommand, error) {
+	d.cmdmut.Lock()
+	var names = make([]string, 0, len(d.commands))
+	for n := range d.commands {
+		if cmdNamePat.MatchString(n) {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	var err error
+	if len(names) > maxCommands {
+		names = names[:maxCommands]
+		err = ErrTooManyCmds
+	}
+
+	var res = make([]*discordgo.ApplicationCommand, 0, len(names))
+	for _, n := range names {
+		var desc = d.commands[n]
+		if desc == "" {
+			desc = fmt.Sprintf("Execute %s command", n)
+		}
+		if r := []rune(desc); len(r) > maxDescription {
+			// Limit is in characters, do not cut a multi-byte character in half
+			desc = string(r[:maxDescription-3]) + "..."
+		}
+
+		res = append(res, &discordgo.ApplicationCommand{
+			Type:        discordgo.ChatApplicationCommand,
+			Name:        n,
+			Description: desc,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "args",
+					Description: "Command arguments",
+				},
+			},
+		})
+	}
+	d.cmdmut.Unlock()
+
+	return res, err
+}
+
+func (d *Gateway) registerCommands(guildID string) {
+	if !d.SlashCommands || d.State.User == nil {
+		return
+	}
+
+	cmds, err := d.applicationCommands()
+	if err != nil {
+		d.Fire(&network.AsyncError{Src: "registerCommands[list]", Err: err})
+	}
+
+	if _, err := d.ApplicationCommandBulkOverwrite(d.State.User.ID, guildID, cmds); err != nil {
+		d.Fire(&network.AsyncError{Src: "registerCommands[overwrite]", Err: err})
+	}
+}
+
+func (d *Gateway) onInteractionCreate(s *discordgo.Session, msg *discordgo.InteractionCreate) {
+	if !d.SlashCommands || msg.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	var data = msg.ApplicationCommandData()
+	var line = data.Name
+	for _, o := range data.Options {
+		if o.Type == discordgo.ApplicationCommandOptionString {
+			line += " " + o.StringValue()
+		}
+	}
+
+	var t = gateway.ExtractTrigger(line)
+
+	if c := d.Channels[msg.ChannelID]; c != nil && msg.Member != nil && msg.Member.User != nil {
+		evUser, err := c.User(msg.Member.User.ID)
+		if err != nil {
+			d.Fire(&network.AsyncError{Src: "onInteractionCreate[user]", Err: err})
+		}
+		if evUser == nil || evUser.Access < c.Commands.Access {
+			d.respondError(msg.Interaction, "No permission to perform action")
+			return
+		}
+
+		t.User = *evUser
+		t.Resp = d.interactionResponder(msg.Interaction, c.Commands.RespondPrivate)
+		c.Fire(t)
+		return
+	}
+
+	if msg.User == nil || msg.GuildID != "" {
+		d.respondError(msg.Interaction, "Commands are not available in this channel")
+		return
+	}
+
+	var u = gateway.User{
+		ID:        msg.User.ID,
+		Name:      msg.User.Username,
+		AvatarURL: msg.User.AvatarURL(""),
+		Access:    d.AccessDM,
+	}
+	if access := d.AccessUser[msg.User.ID]; access != gateway.AccessDefault {
+		u.Access = access
+	}
+
+	if u.Access < d.Commands.Access {
+		d.respondError(msg.Interaction, "No permission to perform action")
+		return
+	}
+
+	t.User = u
+	t.Resp = d.interactionResponder(msg.Interaction, false)
+	d.Fire(t)
+}
+
+func (d *Gateway) respondError(i *discordgo.Interaction, s string) {
+	err := d.InteractionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: s,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		d.Fire(&network.AsyncError{Src: "respondError", Err: err})
+	}
+}
+
+type interaction struct {
+	mut     sync.Mutex
+	pending bool
+	timer   *time.Timer
+
+	session *discordgo.Session
+	inter   *discordgo.Interaction
+	flags   discordgo.MessageFlags
+}
+
+// resp edits the deferred response for the first message, follow-up messages are sent after that
+func (i *interaction) resp(s string) error {
+	if len(s) > 2000 {
+		s = s[:1997] + "..."
+	}
+
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	if i.pending {
+		i.pending = false
+		i.timer.Stop()
+
+		_, err := i.session.InteractionResponseEdit(i.inter, &discordgo.WebhookEdit{Content: &s})
+		return err
+	}
+
+	_, err := i.session.FollowupMessageCreate(i.inter, false, &discordgo.WebhookParams{Content: s, Flags: i.flags})
+	return err
+}
+
+func (i *interaction) expire() error {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	if !i.pending {
+		return nil
+	}
+
+	i.pending = false
+	return i.session.InteractionResponseDelete(i.inter)
+}
+
+// interactionResponder acknowledges an interaction and returns a Responder that replies to it
+func (d *Gateway) interactionResponder(inter *discordgo.Interaction, private bool) gateway.Responder {
+	var i = interaction{
+		pending: true,
+		session: d.Session,
+		inter:   inter,
+	}
+	if private {
+		i.flags = discordgo.MessageFlagsEphemeral
+	}
+
+	err := d.InteractionRespond(inter, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: i.flags},
+	})
+	if err != nil {
+		d.Fire(&network.AsyncError{Src: "interactionResponder[defer]", Err: err})
+		return func(s string) error { return err }
+	}
+
+	i.timer = time.AfterFunc(interactionTimeout, func() {
+		if err := i.expire(); err != nil {
+			d.Fire(&network.AsyncError{Src: "interactionResponder[expire]", Err: err})
+		}
+	})
+
+	return i.resp
+}
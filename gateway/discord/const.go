@@ -68,3 +68,68 @@ func (r *RelayJoinMode) UnmarshalText(text []byte) error {
 func (r RelayJoinMode) MarshalText() ([]byte, error) {
 	return []byte(r.String()), nil
 }
+
+// RoleSyncMode enum
+type RoleSyncMode int32
+
+// RoleSync
+const (
+	RoleSyncSet = 1 << iota
+	RoleSyncReport
+	RoleSyncFix
+)
+
+func (r RoleSyncMode) String() string {
+	var res string
+	if r&RoleSyncSet != 0 {
+		res += "|set"
+		r &= ^RoleSyncSet
+	}
+	if r&RoleSyncReport != 0 {
+		res += "|report"
+		r &= ^RoleSyncReport
+	}
+	if r&RoleSyncFix != 0 {
+		res += "|fix"
+		r &= ^RoleSyncFix
+	}
+	if r != 0 {
+		res += fmt.Sprintf("|0x%02X", uint32(r))
+	}
+	if res != "" {
+		res = res[1:]
+	}
+	return res
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (r *RoleSyncMode) UnmarshalText(text []byte) error {
+	var s = strings.Split(strings.ToLower(string(text)), "|")
+	var t RoleSyncMode
+
+	for _, v := range s {
+		switch v {
+		case "":
+		case "set":
+			t |= RoleSyncSet
+		case "report":
+			t |= RoleSyncReport
+		case "fix":
+			t |= RoleSyncFix
+		default:
+			v, err := strconv.ParseInt(v, 0, 32)
+			if err != nil {
+				return err
+			}
+			t |= RoleSyncMode(v)
+		}
+	}
+
+	*r = t
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (r RoleSyncMode) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
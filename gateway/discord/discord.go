@@ -7,6 +7,7 @@ package discord
 import (
 	"context"
 	"errors"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -22,16 +23,19 @@ import (
 var (
 	ErrSayBufferFull = errors.New("gw-discord: Say buffer full")
 	ErrInvalidGuild  = errors.New("gw-discord: Invalid guild ID")
+	ErrUnknownRole   = errors.New("gw-discord: Unknown role")
+	ErrTooManyCmds   = errors.New("gw-discord: Too many application commands")
 )
 
 // Config stores the configuration of a Discord session
 type Config struct {
 	gateway.Config
-	AuthToken  string
-	Channels   map[string]*ChannelConfig
-	Presence   string
-	AccessDM   gateway.AccessLevel
-	AccessUser map[string]gateway.AccessLevel
+	AuthToken     string
+	Channels      map[string]*ChannelConfig
+	Presence      string
+	SlashCommands bool
+	AccessDM      gateway.AccessLevel
+	AccessUser    map[string]gateway.AccessLevel
 }
 
 // Gateway manages a Discord connection
@@ -44,6 +48,9 @@ type Gateway struct {
 	users   map[string]struct{}
 	guilds  map[string][]string
 
+	cmdmut   sync.Mutex
+	commands map[string]string
+
 	// Set once before Run(), read-only after that
 	*Config
 	Channels map[string]*Channel
@@ -137,6 +144,34 @@ func validateUID(uid string) error {
 	return nil
 }
 
+// restError maps Discord API errors to their gateway equivalent
+func restError(err error) error {
+	rerr, ok := err.(*discordgo.RESTError)
+	if !ok {
+		return err
+	}
+
+	if rerr.Message != nil {
+		switch rerr.Message.Code {
+		case discordgo.ErrCodeMissingPermissions, discordgo.ErrCodeMissingAccess:
+			return gateway.ErrNoPermission
+		case discordgo.ErrCodeUnknownMember, discordgo.ErrCodeUnknownUser, discordgo.ErrCodeUnknownBan:
+			return gateway.ErrNoUser
+		}
+	}
+
+	if rerr.Response != nil {
+		switch rerr.Response.StatusCode {
+		case http.StatusForbidden:
+			return gateway.ErrNoPermission
+		case http.StatusNotFound:
+			return gateway.ErrNoUser
+		}
+	}
+
+	return err
+}
+
 // SetUserAccess overrides accesslevel for a specific user
 func (d *Gateway) SetUserAccess(uid string, a gateway.AccessLevel) (*gateway.AccessLevel, error) {
 	if err := validateUID(uid); err != nil {
@@ -250,6 +285,8 @@ func (d *Gateway) InitDefaultHandlers() {
 	d.AddHandler(d.onPresenceUpdate)
 
 	d.AddHandler(d.onMessageCreate)
+	d.AddHandler(d.onInteractionCreate)
+	d.AddHandler(d.onMessageReactionAdd)
 }
 
 func (d *Gateway) onConnect(s *discordgo.Session, msg *discordgo.Connect) {
@@ -361,11 +398,19 @@ func (d *Gateway) onGuildCreate(s *discordgo.Session, msg *discordgo.GuildCreate
 		d.guilds[ch.GuildID] = append(d.guilds[ch.GuildID], ch.ID)
 
 		c.Fire(c.Channel())
+
+		if c.SyncRoles&(RoleSyncReport|RoleSyncFix) != 0 {
+			go c.reconcileRoles()
+		}
 	}
 
 	for _, p := range msg.Presences {
 		d.updatePresence(msg.Guild.ID, p)
 	}
+
+	if len(d.guilds[msg.Guild.ID]) > 0 {
+		go d.registerCommands(msg.Guild.ID)
+	}
 }
 
 func (d *Gateway) onGuildUpdate(s *discordgo.Session, msg *discordgo.GuildUpdate) {
@@ -486,6 +531,12 @@ func (d *Gateway) onMessageCreate(s *discordgo.Session, msg *discordgo.MessageCr
 	}
 }
 
+func (d *Gateway) onMessageReactionAdd(s *discordgo.Session, msg *discordgo.MessageReactionAdd) {
+	if c := d.Channels[msg.ChannelID]; c != nil {
+		c.onMessageReactionAdd(s, msg)
+	}
+}
+
 // Relay placeholder to implement Gateway interface
 // Events should instead be relayed directly to a Channel
 func (d *Gateway) Relay(ev *network.Event, from gateway.Gateway) error {
@@ -0,0 +1,81 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package discord
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Errors
+var (
+	ErrTooManyOptions = errors.New("gw-discord: Too many poll options")
+)
+
+var pollEmoji = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣", "🔟"}
+
+// SayPoll sends poll message s and adds a reaction for each option
+// vote is called for each reaction until it returns false or closed is closed
+func (c *Channel) SayPoll(s string, options int, vote func(uid string, option int) bool, closed <-chan struct{}) error {
+	if options > len(pollEmoji) {
+		return ErrTooManyOptions
+	}
+
+	msg, err := c.session.ChannelMessageSend(c.ChannelID, "📊 "+s)
+	if err != nil {
+		return err
+	}
+
+	c.pmut.Lock()
+	if c.polls == nil {
+		c.polls = make(map[string]func(uid string, option int) bool)
+	}
+	c.polls[msg.ID] = vote
+	c.pmut.Unlock()
+
+	if closed != nil {
+		go func() {
+			<-closed
+			c.pmut.Lock()
+			delete(c.polls, msg.ID)
+			c.pmut.Unlock()
+		}()
+	}
+
+	for i := 0; i < options; i++ {
+		if err := c.session.MessageReactionAdd(c.ChannelID, msg.ID, pollEmoji[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Channel) onMessageReactionAdd(s *discordgo.Session, msg *discordgo.MessageReactionAdd) {
+	if s.State.User != nil && msg.UserID == s.State.User.ID {
+		return
+	}
+
+	c.pmut.Lock()
+	var vote = c.polls[msg.MessageID]
+	c.pmut.Unlock()
+
+	if vote == nil {
+		return
+	}
+
+	for i, e := range pollEmoji {
+		if e != msg.Emoji.Name {
+			continue
+		}
+		if !vote(msg.UserID, i) {
+			c.pmut.Lock()
+			delete(c.polls, msg.MessageID)
+			c.pmut.Unlock()
+		}
+		return
+	}
+}
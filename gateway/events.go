@@ -79,4 +79,14 @@ type Trigger struct {
 	Raw  []string
 	Arg  []string
 	Resp Responder
+
+	// Number of commands (i.e. aliases) this trigger is executed by
+	Depth int
+
+	usageErr error
+}
+
+// UsageError returns the error of the last ArgSpec.Parse of t that failed, nil if there was none
+func (t *Trigger) UsageError() error {
+	return t.usageErr
 }
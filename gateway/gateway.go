@@ -93,11 +93,28 @@ func (c *Common) Discriminator() string {
 	return s[1]
 }
 
+// TriggerFinder is implemented by gateways that find triggers in chat messages
+type TriggerFinder interface {
+	Triggers() []string
+	TriggerAccess() AccessLevel
+	FindTrigger(s string) *Trigger
+	Chaining() bool
+}
+
 // TriggerConfig for commands
 type TriggerConfig struct {
 	Trigger        string
+	Triggers       []string
 	Access         AccessLevel
 	RespondPrivate bool
+
+	// Split commands on unquoted `;` and `|` operators (i.e. `.whitelist bob; .say welcome bob`)
+	Chain bool
+
+	// Names that address the bot (i.e. `goop, ping`), in addition to its own name
+	// AddressOperator names are only accepted when the bot is channel operator
+	Address         []string
+	AddressOperator []string
 }
 
 // Config common struct
@@ -110,6 +127,30 @@ func (c *Config) Trigger() string {
 	return c.Commands.Trigger
 }
 
+// Triggers returns the main trigger followed by additional triggers
+func (c *Config) Triggers() []string {
+	var res = make([]string, 0, len(c.Commands.Triggers)+1)
+	if c.Commands.Trigger != "" {
+		res = append(res, c.Commands.Trigger)
+	}
+	for _, t := range c.Commands.Triggers {
+		if t != "" {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// TriggerAccess returns the access level required to execute commands
+func (c *Config) TriggerAccess() AccessLevel {
+	return c.Commands.Access
+}
+
+// Chaining returns true if commands can be chained in a single trigger
+func (c *Config) Chaining() bool {
+	return c.Commands.Chain
+}
+
 // Responder for trigger
 func (c *Config) Responder(gw Gateway, uid string, forcePrivate bool) Responder {
 	if !c.Commands.RespondPrivate && !forcePrivate {
@@ -118,7 +159,7 @@ func (c *Config) Responder(gw Gateway, uid string, forcePrivate bool) Responder
 	return func(s string) error { return gw.SayPrivate(uid, s) }
 }
 
-var argPat = regexp.MustCompile(`("(?:\\.|[^\"])*")|('(?:\\.|[^\'])*')|(\S+)(\s*)`)
+var argPat = regexp.MustCompile(`(?:("(?:\\.|[^\"])*")|('(?:\\.|[^\'])*')|(\S+))(\s*)`)
 
 // ExtractTrigger from s
 func ExtractTrigger(s string) *Trigger {
@@ -160,9 +201,71 @@ func FindTrigger(t, s string) *Trigger {
 	return ExtractTrigger(s[len(t):])
 }
 
-// FindTrigger checks if s starts with trigger, return Trigger{} if true
+// FindTrigger checks if s starts with any trigger, return Trigger{} if true
 func (c *Config) FindTrigger(s string) *Trigger {
-	return FindTrigger(c.Commands.Trigger, s)
+	var t = matchTrigger(s, c.Triggers())
+	if t == "" {
+		return nil
+	}
+	return ExtractTrigger(s[len(t):])
+}
+
+// FindAddressedTrigger checks if s addresses the bot (i.e. `goop, ping` or `goop: ping`), return Trigger{} if true
+// Apart from the configured Address names, the bot can be addressed by name (accepts glob pattern)
+func (c *Config) FindAddressedTrigger(s string, name string, operator bool) *Trigger {
+	idx := strings.IndexAny(s, ",:")
+	if idx <= 0 || idx+2 >= len(s) || s[idx+1] != ' ' {
+		return nil
+	}
+
+	var pat = s[:idx]
+	var addr = c.Commands.Address
+	if operator {
+		addr = append(addr[:len(addr):len(addr)], c.Commands.AddressOperator...)
+	}
+
+	var match = false
+	for _, a := range addr {
+		if strings.EqualFold(pat, a) {
+			match = true
+			break
+		}
+	}
+	if !match && name != "" {
+		match, _ = filepath.Match(strings.ToLower(pat), strings.ToLower(name))
+	}
+	if !match {
+		return nil
+	}
+
+	return ExtractTrigger(s[idx+2:])
+}
+
+// matchTrigger returns the longest trigger that s starts with
+func matchTrigger(s string, triggers []string) string {
+	var res = ""
+	for _, t := range triggers {
+		if len(t) > len(res) && strings.HasPrefix(s, t) {
+			res = t
+		}
+	}
+	return res
+}
+
+// Triggers returns all command triggers of gw
+func Triggers(gw Gateway) []string {
+	if f, ok := gw.(TriggerFinder); ok {
+		return f.Triggers()
+	}
+	if t := gw.Trigger(); t != "" {
+		return []string{t}
+	}
+	return nil
+}
+
+// TrimTrigger removes the longest matching trigger prefix from s
+func TrimTrigger(s string, triggers ...string) string {
+	return s[len(matchTrigger(s, triggers)):]
 }
 
 // FindUserInChannel finds user(s) by pattern
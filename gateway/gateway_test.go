@@ -0,0 +1,58 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package gateway_test
+
+import (
+	"testing"
+
+	"github.com/nielsAD/goop/gateway"
+)
+
+func TestFindTrigger(t *testing.T) {
+	var conf = gateway.Config{Commands: gateway.TriggerConfig{
+		Trigger:         ".",
+		Triggers:        []string{"!", ".."},
+		Address:         []string{"goop", "all"},
+		AddressOperator: []string{"ops"},
+	}}
+
+	var cases = map[string]string{
+		".say hi":  "say",
+		"!say hi":  "say",
+		"..say hi": "say",
+		"?say hi":  "",
+		"say hi":   "",
+	}
+	for s, cmd := range cases {
+		var trig = conf.FindTrigger(s)
+		if (trig == nil) != (cmd == "") || trig != nil && trig.Cmd != cmd {
+			t.Fatalf("%s: expected `%s`, got %+v", s, cmd, trig)
+		}
+	}
+
+	var addr = []struct {
+		s        string
+		operator bool
+		cmd      string
+	}{
+		{"goop, say hi", false, "say"},
+		{"ALL: say hi", false, "say"},
+		{"ops, say hi", false, ""},
+		{"ops, say hi", true, "say"},
+		{"Nie*: say hi", false, "say"},
+		{"bob, say hi", false, ""},
+		{"goop,say hi", false, ""},
+	}
+	for _, c := range addr {
+		var trig = conf.FindAddressedTrigger(c.s, "NielsAD", c.operator)
+		if (trig == nil) != (c.cmd == "") || trig != nil && trig.Cmd != c.cmd {
+			t.Fatalf("%s: expected `%s`, got %+v", c.s, c.cmd, trig)
+		}
+	}
+
+	if s := gateway.TrimTrigger("..say", conf.Triggers()...); s != "say" {
+		t.Fatalf("TrimTrigger: expected `say`, got `%s`", s)
+	}
+}
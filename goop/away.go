@@ -0,0 +1,195 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// AwayConfig stores away users per gateway and user ID
+type AwayConfig struct {
+	// Minimum time between automatic replies for the same user in the same channel
+	Interval time.Duration
+
+	// Maximum number of mentions stored per user
+	MaxMentions int
+
+	Users map[string]map[string]*Away
+}
+
+// Away status of a user
+type Away struct {
+	Name     string
+	Message  string
+	Since    time.Time
+	Mentions []*AwayMention
+}
+
+// AwayMention is a message that mentioned an away user
+type AwayMention struct {
+	Time    time.Time
+	Gateway string
+	User    string
+	Message string
+}
+
+type aways struct {
+	mut     sync.Mutex
+	replied map[string]time.Time
+}
+
+// SetAway marks user u on gw as away with message msg
+func (g *Goop) SetAway(gw gateway.Gateway, u *gateway.User, msg string) {
+	if g.Config == nil {
+		return
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetAway()
+	if conf.Users == nil {
+		conf.Users = make(map[string]map[string]*Away)
+	}
+	if conf.Users[gw.ID()] == nil {
+		conf.Users[gw.ID()] = make(map[string]*Away)
+	}
+
+	var a = conf.Users[gw.ID()][u.ID]
+	if a == nil {
+		a = &Away{Since: time.Now()}
+		conf.Users[gw.ID()][u.ID] = a
+	}
+	a.Name = u.Name
+	a.Message = msg
+}
+
+// ClearAway removes the away status of uid on gw, returns nil if they were not away
+func (g *Goop) ClearAway(gw gateway.Gateway, uid string) *Away {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var users = g.Config.GetAway().Users[gw.ID()]
+	var a = users[uid]
+	if a == nil {
+		return nil
+	}
+
+	delete(users, uid)
+	return a
+}
+
+func mentionsUser(msg string, name string, uid string) bool {
+	if strings.Contains(msg, "<@"+uid+">") || strings.Contains(msg, "<@!"+uid+">") {
+		return true
+	}
+	var n = strings.ToLower(name)
+	return HighlightMatch([]string{n, "@" + n}, msg) != ""
+}
+
+type awayReply struct {
+	key  string
+	away Away
+}
+
+func (g *Goop) onAway(ev *network.Event) {
+	var msg = ev.Arg.(*gateway.Chat)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok || g.Config == nil {
+		return
+	}
+
+	if a := g.ClearAway(gw, msg.User.ID); a != nil {
+		var d = time.Since(a.Since).Round(time.Second)
+
+		var resp = gw.Responder(gw, msg.User.ID, true)
+		if err := resp(fmt.Sprintf("Welcome back, you were away for %s and mentioned %d time(s)", d, len(a.Mentions))); err != nil {
+			g.Fire(&network.AsyncError{Src: "onAway", Err: err})
+		}
+		for _, m := range a.Mentions {
+			if err := resp(fmt.Sprintf("[%s] %s ago <%s> %s", m.Gateway, time.Since(m.Time).Round(time.Second), m.User, m.Message)); err != nil {
+				g.Fire(&network.AsyncError{Src: "onAway", Err: err})
+				break
+			}
+		}
+	}
+
+	var now = time.Now()
+	var where = gw.Discriminator()
+	if c := gw.Channel(); c != nil {
+		where = c.Name
+	}
+
+	g.ConfigMut.Lock()
+	var conf = g.Config.GetAway()
+	var interval = conf.Interval
+	var replies = []awayReply{}
+	for gid, users := range conf.Users {
+		// Only consider mentions that are relayed to the away user
+		if !g.RelaysChat(gid, gw.ID(), msg.User.Access) {
+			continue
+		}
+
+		for uid, a := range users {
+			if a == nil || !mentionsUser(msg.Content, a.Name, uid) {
+				continue
+			}
+
+			a.Mentions = append(a.Mentions, &AwayMention{
+				Time:    now,
+				Gateway: where,
+				User:    msg.User.Name,
+				Message: msg.Content,
+			})
+			if conf.MaxMentions > 0 && len(a.Mentions) > conf.MaxMentions {
+				a.Mentions = append([]*AwayMention{}, a.Mentions[len(a.Mentions)-conf.MaxMentions:]...)
+			}
+
+			replies = append(replies, awayReply{
+				key:  gw.ID() + gateway.Delimiter + gid + gateway.Delimiter + uid,
+				away: *a,
+			})
+		}
+	}
+	g.ConfigMut.Unlock()
+
+	if len(replies) == 0 {
+		return
+	}
+
+	g.aways.mut.Lock()
+	if g.aways.replied == nil {
+		g.aways.replied = make(map[string]time.Time)
+	}
+	var send = replies[:0]
+	for _, r := range replies {
+		if now.Sub(g.aways.replied[r.key]) < interval {
+			continue
+		}
+		g.aways.replied[r.key] = now
+		send = append(send, r)
+	}
+	g.aways.mut.Unlock()
+
+	for _, r := range send {
+		var s = fmt.Sprintf("%s is away (%s ago)", r.away.Name, now.Sub(r.away.Since).Round(time.Second))
+		if r.away.Message != "" {
+			s += ": " + r.away.Message
+		}
+		if err := gw.Say(s); err != nil {
+			g.Fire(&network.AsyncError{Src: "onAway", Err: err})
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/nielsAD/goop/gateway"
+)
+
+// Chain operators
+const (
+	OpSequence = ";"
+	OpPipe     = "|"
+)
+
+// Stage of a command chain
+type Stage struct {
+	*gateway.Trigger
+
+	// Pipe output into next stage
+	Pipe bool
+}
+
+func quoted(raw string) bool {
+	return strings.HasPrefix(raw, "\"") || strings.HasPrefix(raw, "'")
+}
+
+// SplitTrigger splits t into stages separated by unquoted OpSequence or OpPipe operators
+// (i.e. `.whitelist bob; .say welcome bob` or `.who | .say`)
+// The trigger prefix of subsequent stages is optional
+func SplitTrigger(t *gateway.Trigger, triggers ...string) []*Stage {
+	var res []*Stage
+	var cur = &gateway.Trigger{User: t.User, Cmd: t.Cmd, Resp: t.Resp}
+	var expectCmd = false
+
+	var end = func(pipe bool) {
+		if n := len(cur.Raw); n > 0 {
+			cur.Raw[n-1] = strings.TrimRightFunc(cur.Raw[n-1], unicode.IsSpace)
+		}
+		if cur.Cmd != "" {
+			res = append(res, &Stage{Trigger: cur, Pipe: pipe})
+		}
+		cur = &gateway.Trigger{User: t.User, Resp: t.Resp}
+		expectCmd = true
+	}
+
+	if strings.HasSuffix(cur.Cmd, OpSequence) {
+		cur.Cmd = strings.TrimSuffix(cur.Cmd, OpSequence)
+		end(false)
+	}
+
+	for i, raw := range t.Raw {
+		var arg = t.Arg[i]
+		if !quoted(raw) {
+			var tok = strings.TrimSpace(raw)
+			switch {
+			case tok == OpSequence:
+				end(false)
+				continue
+			case tok == OpPipe:
+				end(true)
+				continue
+			case strings.HasSuffix(tok, OpSequence):
+				arg = strings.TrimSuffix(tok, OpSequence)
+				if expectCmd {
+					cur.Cmd = gateway.TrimTrigger(arg, triggers...)
+					expectCmd = false
+				} else {
+					cur.Raw = append(cur.Raw, arg)
+					cur.Arg = append(cur.Arg, arg)
+				}
+				end(false)
+				continue
+			}
+		}
+
+		if expectCmd {
+			cur.Cmd = gateway.TrimTrigger(arg, triggers...)
+			expectCmd = false
+			continue
+		}
+
+		cur.Raw = append(cur.Raw, raw)
+		cur.Arg = append(cur.Arg, arg)
+	}
+
+	if len(res) == 0 {
+		// No operators, leave trigger untouched
+		return []*Stage{&Stage{Trigger: t}}
+	}
+
+	end(false)
+	return res
+}
+
+// stages splits t into a chain if gw has chaining enabled, otherwise t is the only stage
+func stages(t *gateway.Trigger, gw gateway.Gateway) []*Stage {
+	if f, ok := gw.(gateway.TriggerFinder); ok && f.Chaining() {
+		return SplitTrigger(t, f.Triggers()...)
+	}
+	return []*Stage{{Trigger: t}}
+}
+
+// execChain fires stages in order as Trigger events, aborting if a stage could not be executed
+// The output of piped stages is captured and appended as argument to the next stage
+func (g *Goop) execChain(chain []*Stage, gw gateway.Gateway) error {
+	var in []string
+	for _, s := range chain {
+		var t = *s.Trigger
+
+		if len(in) > 0 {
+			var arg = strings.Join(in, "\n")
+			t.Arg = append(append([]string{}, t.Arg...), arg)
+			t.Raw = append([]string{}, t.Raw...)
+			if n := len(t.Raw); n > 0 && !strings.HasSuffix(t.Raw[n-1], " ") {
+				t.Raw[n-1] += " "
+			}
+			t.Raw = append(t.Raw, arg)
+		}
+
+		var mut sync.Mutex
+		var out []string
+		if s.Pipe {
+			t.Resp = func(s string) error {
+				mut.Lock()
+				out = append(out, s)
+				mut.Unlock()
+				return nil
+			}
+		}
+
+		ok, err := g.Exec(&t, gw)
+		if err != nil || !ok {
+			return err
+		}
+
+		in = out
+	}
+	return nil
+}
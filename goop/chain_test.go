@@ -0,0 +1,40 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestSplitTrigger(t *testing.T) {
+	var cases = []struct {
+		in   string
+		cmds []string
+		args [][]string
+		pipe []bool
+	}{
+		{"say hello world", []string{"say"}, [][]string{{"hello", "world"}}, []bool{false}},
+		{"whitelist bob; .say welcome bob", []string{"whitelist", "say"}, [][]string{{"bob"}, {"welcome", "bob"}}, []bool{false, false}},
+		{"who | .say", []string{"who", "say"}, [][]string{nil, nil}, []bool{true, false}},
+		{"time; uptime ;", []string{"time", "uptime"}, [][]string{nil, nil}, []bool{false, false}},
+		{`say "a; b" | echo`, []string{"say", "echo"}, [][]string{{"a; b"}, nil}, []bool{true, false}},
+	}
+
+	for _, c := range cases {
+		var s = goop.SplitTrigger(gateway.ExtractTrigger(c.in), ".")
+		if len(s) != len(c.cmds) {
+			t.Fatalf("%s: expected %d stages, got %d", c.in, len(c.cmds), len(s))
+		}
+		for i := range s {
+			if s[i].Cmd != c.cmds[i] || s[i].Pipe != c.pipe[i] || (len(s[i].Arg) > 0 || len(c.args[i]) > 0) && !reflect.DeepEqual(s[i].Arg, c.args[i]) {
+				t.Fatalf("%s: unexpected stage %d: %+v", c.in, i, s[i])
+			}
+		}
+	}
+}
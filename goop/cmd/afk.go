@@ -0,0 +1,37 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Afk marks a user as away until they talk again
+type Afk struct{ Cmd }
+
+var afkArgs = gateway.MustParseArgSpec("message:rest?")
+
+// Usage of command
+func (c *Afk) Usage() string { return afkArgs.Usage() }
+
+// Description of command
+func (c *Afk) Description() string { return "Mark yourself as away" }
+
+// Execute command
+func (c *Afk) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := afkArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var msg = args.Get("message")
+	g.SetAway(gw, &t.User, msg)
+
+	if msg == "" {
+		return t.Resp("You are now away")
+	}
+	return t.Resp("You are now away: " + msg)
+}
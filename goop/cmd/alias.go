@@ -6,9 +6,12 @@ package cmd
 
 import (
 	"fmt"
+	"math/rand"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/nielsAD/goop/gateway"
 	"github.com/nielsAD/goop/goop"
@@ -20,9 +23,30 @@ type Alias struct {
 	Exe            string
 	Arg            []string
 	ArgExpected    int
+	Args           string
+	ArgUsage       string
+	Help           string
 	WithPriviledge gateway.AccessLevel
 }
 
+// Usage of command, defaults to the usage of Args spec
+func (c *Alias) Usage() string {
+	if c.ArgUsage == "" && c.Args != "" {
+		if spec, err := gateway.ParseArgSpec(c.Args); err == nil {
+			return spec.Usage()
+		}
+	}
+	return c.ArgUsage
+}
+
+// Description of command, defaults to the aliased command
+func (c *Alias) Description() string {
+	if c.Help != "" {
+		return c.Help
+	}
+	return fmt.Sprintf("Alias for %s", c.Exe)
+}
+
 // Replacer callback
 type Replacer func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string
 
@@ -58,64 +82,163 @@ var Placeholders = map[string]Replacer{
 	"%GWDEL%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 		return gateway.Delimiter
 	},
+	"%CHAN%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
+		if c := gw.Channel(); c != nil {
+			return c.Name
+		}
+		return ""
+	},
+	"%TIME%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
+		return time.Now().Format("15:04:05 MST")
+	},
+	"%DATE%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
+		return time.Now().Format("2006-01-02")
+	},
+	"%RAND000%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
+		n, err := strconv.Atoi(replacersInt.FindString(m))
+		if err != nil || n <= 0 {
+			return m
+		}
+		return strconv.Itoa(rand.Intn(n) + 1)
+	},
 	"%RARG000%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 		idx, err := strconv.Atoi(replacersInt.FindString(m))
-		if err != nil || idx <= 0 || len(t.Raw) <= idx {
+		if err != nil || idx <= 0 || len(t.Raw) < idx {
 			return m
 		}
 		return t.Raw[idx-1]
 	},
 	"%..RARG000%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 		idx, err := strconv.Atoi(replacersInt.FindString(m))
-		if err != nil || idx <= 0 || len(t.Raw) <= idx {
+		if err != nil || idx <= 0 || len(t.Raw) < idx-1 {
 			return m
 		}
 		return strings.Join(t.Raw[:idx-1], "")
 	},
 	"%RARG000..%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 		idx, err := strconv.Atoi(replacersInt.FindString(m))
-		if err != nil || idx <= 0 || len(t.Raw) <= idx {
+		if err != nil || idx <= 0 || len(t.Raw) < idx {
 			return m
 		}
 		return strings.Join(t.Raw[idx-1:], "")
 	},
 	"%ARG000%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 		idx, err := strconv.Atoi(replacersInt.FindString(m))
-		if err != nil || idx <= 0 || len(t.Arg) <= idx {
+		if err != nil || idx <= 0 || len(t.Arg) < idx {
 			return m
 		}
 		return t.Arg[idx-1]
 	},
 	"%..ARG000%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 		idx, err := strconv.Atoi(replacersInt.FindString(m))
-		if err != nil || idx <= 0 || len(t.Arg) <= idx {
+		if err != nil || idx <= 0 || len(t.Arg) < idx-1 {
 			return m
 		}
 		return strings.Join(t.Arg[:idx-1], " ")
 	},
 	"%ARG000..%": func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 		idx, err := strconv.Atoi(replacersInt.FindString(m))
-		if err != nil || idx <= 0 || len(t.Arg) <= idx {
+		if err != nil || idx <= 0 || len(t.Arg) < idx {
 			return m
 		}
 		return strings.Join(t.Arg[idx-1:], " ")
 	},
 }
 
+// Placeholders with branches ({...}), branches may contain other placeholders
+func init() {
+	Placeholders["%CHOICE{}%"] = func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
+		var b = branches(m)
+		return Replace(b[rand.Intn(len(b))], t, gw, g)
+	}
+	Placeholders["%IFARGS000{}%"] = func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
+		n, err := strconv.Atoi(replacersInt.FindString(m))
+		if err != nil {
+			return m
+		}
+		var b = branches(m)
+		if len(t.Arg) >= n {
+			return Replace(b[0], t, gw, g)
+		}
+		if len(b) > 1 {
+			return Replace(b[1], t, gw, g)
+		}
+		return ""
+	}
+	Placeholders["%EXEC{}%"] = func(m string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
+		var s = gateway.TrimTrigger(Replace(branches(m)[0], t, gw, g), gateway.Triggers(gw)...)
+		var trig = gateway.ExtractTrigger(s)
+		if trig == nil {
+			return ""
+		}
+
+		var mut sync.Mutex
+		var out []string
+
+		// Exec limits the depth, in case aliases execute each other
+		trig.User = t.User
+		trig.Depth = t.Depth
+		trig.Resp = func(s string) error {
+			mut.Lock()
+			out = append(out, s)
+			mut.Unlock()
+			return nil
+		}
+		if _, err := g.Exec(trig, gw); err != nil {
+			return ""
+		}
+
+		mut.Lock()
+		defer mut.Unlock()
+		return strings.Join(out, " ")
+	}
+
+	replacersPat = regexp.MustCompile((func() string {
+		var s = []string{}
+		for k := range Placeholders {
+			var p = strings.Replace(regexp.QuoteMeta(k), "000", `\d+`, -1)
+			p = strings.Replace(p, `\{\}`, replacersGrp.String(), -1)
+			s = append(s, p[:len(p)-1]+`(?:\|[^%{}]*)?%`)
+		}
+		return strings.Join(s, "|")
+	}()))
+}
+
 var replacersInt = regexp.MustCompile(`\d+`)
-var replacersPat = regexp.MustCompile((func() string {
-	var s = []string{}
-	for k := range Placeholders {
-		s = append(s, strings.Replace(regexp.QuoteMeta(k), "000", "\\d+", -1))
+var replacersBrc = regexp.MustCompile(`\{[^{}]*\}`)
+var replacersGrp = regexp.MustCompile(`(?:\{[^{}]*\})+`)
+var replacersPat *regexp.Regexp
+
+func branches(m string) []string {
+	var b = replacersBrc.FindAllString(m, -1)
+	for i := range b {
+		b[i] = b[i][1 : len(b[i])-1]
 	}
-	return strings.Join(s, "|")
-}()))
+	return b
+}
 
 // Replace all placeholders
+// Placeholders followed by |default (i.e. %ARG1|everyone%) are replaced with default if they cannot be resolved
 func Replace(s string, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) string {
 	return replacersPat.ReplaceAllStringFunc(s, func(s string) string {
-		var idx = replacersInt.ReplaceAllString(s, "000")
-		return Placeholders[idx](s, t, gw, g)
+		var key = replacersGrp.ReplaceAllString(s, "{}")
+
+		var def string
+		var hasDef = false
+		if i := strings.LastIndexByte(key, '|'); i >= 0 && !strings.Contains(key[i:], "}") {
+			def, hasDef = key[i+1:len(key)-1], true
+			key = key[:i] + "%"
+			s = s[:strings.LastIndexByte(s, '|')] + "%"
+		}
+
+		var idx = strings.SplitN(key, "{", 2)
+		idx[0] = replacersInt.ReplaceAllString(idx[0], "000")
+
+		var res = Placeholders[strings.Join(idx, "{")](s, t, gw, g)
+		if hasDef && (res == s || res == "") {
+			return def
+		}
+		return res
 	})
 }
 
@@ -128,6 +251,28 @@ func (c *Alias) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) er
 		return t.Resp(fmt.Sprintf("Expected %d arguments", c.ArgExpected))
 	}
 
+	if c.Args != "" {
+		spec, err := gateway.ParseArgSpec(c.Args)
+		if err != nil {
+			t.Resp(MsgInternalError)
+			return err
+		}
+		args, err := spec.Parse(t, gw)
+		if err != nil {
+			return t.Resp(err.Error())
+		}
+
+		// Placeholders operate on validated arguments (with defaults applied)
+		var norm = *t
+		norm.Arg = args.Positional()
+		norm.Raw = make([]string, len(norm.Arg))
+		for i, a := range norm.Arg {
+			norm.Raw[i] = a + " "
+		}
+		t = &norm
+		trig.Arg, trig.Raw = norm.Arg, norm.Raw
+	}
+
 	if c.Arg != nil {
 		trig.Raw = make([]string, len(c.Arg))
 		trig.Arg = make([]string, len(c.Arg))
@@ -142,6 +287,6 @@ func (c *Alias) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) er
 		trig.User.Access = c.WithPriviledge
 	}
 
-	gw.Fire(&trig)
-	return nil
+	_, err := g.Exec(&trig, gw)
+	return err
 }
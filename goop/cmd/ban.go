@@ -15,30 +15,54 @@ import (
 // Ban user
 type Ban struct {
 	Cmd
+	ConfirmConfig
 	AccessProtect  gateway.AccessLevel
 	AccessOverride gateway.AccessLevel
 }
 
+var banArgs = gateway.MustParseArgSpec("username:chanuser --dry-run")
+
+// Usage of command
+func (c *Ban) Usage() string { return banArgs.Usage() }
+
+// Description of command
+func (c *Ban) Description() string { return "Ban user from channel" }
+
 // Execute command
 func (c *Ban) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 1 {
-		return t.Resp("Expected 1 argument: [user]")
+	args, err := banArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
-	var users = gateway.FindUserInChannel(gw, t.Arg[0])
+	var users = args.Users("username")
 	if len(users) == 0 {
-		users = []*gateway.User{&gateway.User{ID: t.Arg[0], Name: t.Arg[0]}}
+		users = []*gateway.User{&gateway.User{ID: args.Get("username"), Name: args.Get("username")}}
+	}
+
+	if ok, err := c.preview("ban", args.Bool("dry-run"), unprotected(users, t, c.protected), t, gw, g, func() error { return c.ban(t, gw, g, users) }); ok {
+		return err
 	}
+	return c.ban(t, gw, g, users)
+}
+
+func (c *Ban) protected(t *gateway.Trigger, u *gateway.User) bool {
+	return u.ID == t.User.ID || u.Access >= t.User.Access || (u.Access >= c.AccessProtect && t.User.Access < c.AccessOverride)
+}
 
+func (c *Ban) ban(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop, users []*gateway.User) error {
 	var p = 0
 	var l = []string{}
 
+	var undo []goop.ModAction
+	defer func() { g.RecordMod(gw, &t.User, undo) }()
+
 	for _, u := range users {
-		if u.ID == t.User.ID || u.Access >= t.User.Access || (u.Access >= c.AccessProtect && t.User.Access < c.AccessOverride) {
+		if c.protected(t, u) {
 			p++
 			continue
 		}
 
-		_, err := gw.SetUserAccess(u.ID, gateway.AccessBan)
+		prev, err := gw.SetUserAccess(u.ID, gateway.AccessBan)
 		switch err {
 		case nil, gateway.ErrNotImplemented:
 			// no error
@@ -54,7 +78,11 @@ func (c *Ban) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) erro
 		switch err {
 		case nil, gateway.ErrNoUser:
 			l = append(l, fmt.Sprintf("`%s`", u.Name))
+			undo = append(undo, goop.ModAction{Gateway: gw.ID(), User: *u, Prev: prev, Ban: true})
 		case gateway.ErrNotImplemented, gateway.ErrNoChannel:
+			if prev != nil {
+				undo = append(undo, goop.ModAction{Gateway: gw.ID(), User: *u, Prev: prev})
+			}
 			return nil
 		case gateway.ErrNoPermission:
 			return t.Resp(MsgNoPermission)
@@ -84,27 +112,41 @@ type Unban struct {
 	AccessOverride gateway.AccessLevel
 }
 
+var unbanArgs = gateway.MustParseArgSpec("username:user")
+
+// Usage of command
+func (c *Unban) Usage() string { return unbanArgs.Usage() }
+
+// Description of command
+func (c *Unban) Description() string { return "Unban user from channel" }
+
 // Execute command
 func (c *Unban) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 1 {
-		return t.Resp("Expected 1 argument: [user]")
+	args, err := unbanArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
-	var users = gateway.FindUser(gw, t.Arg[0])
+	var users = args.Users("username")
 	if len(users) == 0 {
-		users = []*gateway.User{&gateway.User{ID: t.Arg[0], Name: t.Arg[0]}}
+		users = []*gateway.User{&gateway.User{ID: args.Get("username"), Name: args.Get("username")}}
 	}
 
 	var p = 0
 	var l = []string{}
 
+	var undo []goop.ModAction
+	defer func() { g.RecordMod(gw, &t.User, undo) }()
+
 	for _, u := range users {
 		if u.ID == t.User.ID || (u.Access <= c.AccessProtect && t.User.Access < c.AccessOverride) {
 			p++
 			continue
 		}
 
+		var prev *gateway.AccessLevel
 		if u.Access < gateway.AccessDefault {
-			_, err := gw.SetUserAccess(u.ID, gateway.AccessDefault)
+			var err error
+			prev, err = gw.SetUserAccess(u.ID, gateway.AccessDefault)
 			switch err {
 			case nil, gateway.ErrNotImplemented:
 				// no error
@@ -121,7 +163,11 @@ func (c *Unban) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) er
 		switch err {
 		case nil, gateway.ErrNoUser:
 			l = append(l, fmt.Sprintf("`%s`", u.Name))
+			undo = append(undo, goop.ModAction{Gateway: gw.ID(), User: *u, Prev: prev, Unban: true})
 		case gateway.ErrNotImplemented, gateway.ErrNoChannel:
+			if prev != nil {
+				undo = append(undo, goop.ModAction{Gateway: gw.ID(), User: *u, Prev: prev})
+			}
 			return nil
 		case gateway.ErrNoPermission:
 			return t.Resp(MsgNoPermission)
@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/nielsAD/goop/gateway"
 	"github.com/nielsAD/goop/goop"
@@ -22,8 +23,13 @@ const (
 
 // Cmd is command base struct that implements Command.CanExecute
 type Cmd struct {
-	Disabled   bool
-	Priviledge gateway.AccessLevel
+	Disabled       bool
+	Priviledge     gateway.AccessLevel
+	Cooldown       time.Duration
+	GlobalCooldown time.Duration
+
+	// Triggers for this command in addition to the gateway triggers (empty string for no prefix)
+	Triggers []string
 }
 
 // CanExecute returns true if t.Access >= c.Access
@@ -31,6 +37,16 @@ func (c *Cmd) CanExecute(t *gateway.Trigger) bool {
 	return !c.Disabled && t.User.Access >= c.Priviledge
 }
 
+// TriggerOverride returns the additional triggers for this command
+func (c *Cmd) TriggerOverride() []string {
+	return c.Triggers
+}
+
+// Cooldowns returns the per-user and global cooldown period
+func (c *Cmd) Cooldowns() (time.Duration, time.Duration) {
+	return c.Cooldown, c.GlobalCooldown
+}
+
 // Commands listing
 type Commands struct {
 	Trigger    Trigger
@@ -45,11 +61,35 @@ type Commands struct {
 	Kick       Kick
 	Ban        Ban
 	Unban      Unban
+	Undo       Undo
+	Confirm    Confirm
+	Lockdown   Lockdown
+	Gateway    Gateway
+	Join       Join
 	Where      Where
 	Who        Who
 	Ping       Ping
 	Time       Time
 	Uptime     Uptime
+	Seen       Seen
+	Tell       Tell
+	Inbox      Inbox
+	Clear      Clear
+	Poll       Poll
+	Vote       Vote
+	Schedule   Schedule
+	Trivia     Trivia
+	Points     Points
+	Top        Top
+	Give       Give
+	Redeem     Redeem
+	Highlight  Highlight
+	History    History
+	Afk        Afk
+	RemindMe   RemindMe
+	Remind     Remind
+	Reminders  Reminders
+	Help       Help
 }
 
 // AddTo goop
@@ -5,8 +5,15 @@
 package cmd_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/gateway/stdio"
 	"github.com/nielsAD/goop/goop"
 	"github.com/nielsAD/goop/goop/cmd"
 )
@@ -15,4 +22,77 @@ func TestInterface(t *testing.T) {
 	var g = goop.New(nil)
 	var c cmd.Commands
 	c.AddTo(g)
+
+	var v = reflect.ValueOf(&c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if _, ok := v.Field(i).Addr().Interface().(goop.CommandInfo); !ok {
+			t.Fatalf("%s does not implement CommandInfo", v.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	var trig = gateway.ExtractTrigger(`cmd foo "bar baz"`)
+	var cases = map[string]string{
+		"%ARG1%":                      "foo",
+		"%ARG2%":                      "bar baz",
+		"%ARG3|everyone%":             "everyone",
+		"%ARG1..%":                    "foo bar baz",
+		"%..ARG3%":                    "foo bar baz",
+		"%IFARGS2{two: %ARG2%}{one}%": "two: bar baz",
+		"%IFARGS3{three}{%NARGS%}%":   "2",
+		"%IFARGS3{three}%":            "",
+		"%CHOICE{%ARG1%}%":            "foo",
+	}
+	for in, out := range cases {
+		if s := cmd.Replace(in, trig, nil, nil); s != out {
+			t.Fatalf("%s: expected %q, got %q", in, out, s)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if s := cmd.Replace("%RAND2%", trig, nil, nil); s != "1" && s != "2" {
+			t.Fatalf("RAND: unexpected %q", s)
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	var g = goop.New(nil)
+	var gw = stdio.New(ioutil.NopCloser(&bytes.Buffer{}), log.New(ioutil.Discard, "", 0), &stdio.Config{
+		Config: gateway.Config{Commands: gateway.TriggerConfig{Trigger: "."}},
+	})
+	if err := g.AddGateway("std"+gateway.Delimiter+"test", gw); err != nil {
+		t.Fatal(err)
+	}
+
+	g.AddCommand("echo", &cmd.Echo{})
+	g.AddCommand("hello", &cmd.Alias{Exe: "echo", Arg: []string{"hello %ARG1|world%"}})
+	g.AddCommand("ping", &cmd.Alias{Exe: "echo", Arg: []string{"%EXEC{pong}%"}})
+	g.AddCommand("pong", &cmd.Alias{Exe: "echo", Arg: []string{"%EXEC{ping}%"}})
+	g.AddCommand("self", &cmd.Alias{Exe: "echo", Arg: []string{"%EXEC{self}%"}})
+	g.AddCommand("a", &cmd.Alias{Exe: "b"})
+	g.AddCommand("b", &cmd.Alias{Exe: "a"})
+
+	var trig = gateway.ExtractTrigger("cmd")
+	var cases = map[string]string{
+		"%EXEC{echo foo}%":               "foo",
+		"%EXEC{.hello}%":                 "hello world",
+		"%EXEC{hello niels}%":            "hello niels",
+		"%EXEC{unknown}%":                "",
+		"%EXEC{self}%":                   "",
+		"%EXEC{ping}%":                   "",
+		"%EXEC{pong}% %EXEC{echo done}%": "done",
+	}
+	for in, out := range cases {
+		// Aliases append a space to each argument
+		if s := strings.TrimSpace(cmd.Replace(in, trig, gw, g)); s != out {
+			t.Fatalf("%s: expected %q, got %q", in, out, s)
+		}
+	}
+
+	trig = gateway.ExtractTrigger("a")
+	trig.Resp = func(s string) error { return nil }
+	if _, err := g.Exec(trig, gw); err != goop.ErrExecDepth {
+		t.Fatalf("Exec(a): expected ErrExecDepth, got %v", err)
+	}
 }
@@ -0,0 +1,75 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// ConfirmConfig for commands that act on user patterns
+type ConfirmConfig struct {
+	ConfirmAbove   int
+	ConfirmTimeout time.Duration
+}
+
+func userList(users []*gateway.User) string {
+	var l = make([]string, len(users))
+	for i, u := range users {
+		l[i] = fmt.Sprintf("`%s`", u.Name)
+	}
+	return "[" + strings.Join(l, ", ") + "]"
+}
+
+// unprotected returns users that are not protected from t.User
+func unprotected(users []*gateway.User, t *gateway.Trigger, protected func(t *gateway.Trigger, u *gateway.User) bool) []*gateway.User {
+	var res = make([]*gateway.User, 0, len(users))
+	for _, u := range users {
+		if !protected(t, u) {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
+// preview returns true if action is postponed because of a dry run, or because it requires confirmation
+func (c *ConfirmConfig) preview(verb string, dry bool, users []*gateway.User, t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop, action func() error) (bool, error) {
+	if dry {
+		return true, t.Resp(fmt.Sprintf("Would %s %s", verb, userList(users)))
+	}
+	if c.ConfirmAbove <= 0 || len(users) <= c.ConfirmAbove {
+		return false, nil
+	}
+
+	var timeout = c.ConfirmTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	g.RequestConfirm(gw, &t.User, timeout, action)
+	return true, t.Resp(fmt.Sprintf("Pattern matches %d users %s, type %sconfirm within %s to %s them", len(users), userList(users), gw.Trigger(), timeout, verb))
+}
+
+// Confirm executes pending action
+type Confirm struct{ Cmd }
+
+// Usage of command
+func (c *Confirm) Usage() string { return "" }
+
+// Description of command
+func (c *Confirm) Description() string { return "Execute your pending action" }
+
+// Execute command
+func (c *Confirm) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	var err = g.Confirm(gw, &t.User)
+	if err == goop.ErrNothingToConfirm {
+		return t.Resp("Nothing to confirm")
+	}
+	return err
+}
@@ -0,0 +1,122 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Gateway starts, stops, connects, disconnects, or reconnects gateways
+type Gateway struct{ Cmd }
+
+var gatewayArgs = gateway.MustParseArgSpec("action id")
+
+// Usage of command
+func (c *Gateway) Usage() string { return "[start|stop|connect|disconnect|reconnect] [id]" }
+
+// Description of command
+func (c *Gateway) Description() string { return "Start, stop, or reconnect gateways" }
+
+// Execute command
+func (c *Gateway) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := gatewayArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var f func(id string) error
+	var verb string
+	switch strings.ToLower(args.Get("action")) {
+	case "start":
+		ids, err := g.StartGateway(args.Get("id"))
+		switch err {
+		case nil:
+			return t.Resp(fmt.Sprintf("Started %s", idList(ids)))
+		case goop.ErrUnknownGateway:
+			return t.Resp("No gateway configuration found with that id")
+		case goop.ErrDuplicateGateway:
+			return t.Resp("Gateway already started")
+		default:
+			return t.Resp(err.Error())
+		}
+	case "stop":
+		f, verb = g.RemoveGateway, "Stopped"
+	case "connect":
+		f, verb = g.Connect, "Connecting"
+	case "disconnect":
+		f, verb = g.Disconnect, "Disconnecting"
+	case "reconnect":
+		f, verb = g.Reconnect, "Reconnecting"
+	default:
+		return t.Resp(fmt.Sprintf("Unknown action, expected %s", c.Usage()))
+	}
+
+	var ids = g.FindGateways(args.Get("id"))
+	if len(ids) == 0 {
+		return t.Resp("No gateway found with that id")
+	}
+
+	var res = []string{}
+	for _, id := range ids {
+		switch err := f(id); err {
+		case nil:
+			res = append(res, id)
+		case goop.ErrGatewayRunning, goop.ErrGatewayNotRunning, goop.ErrUnknownGateway:
+			// skip
+		default:
+			return err
+		}
+	}
+
+	if len(res) == 0 {
+		return t.Resp(MsgNoChanges)
+	}
+	return t.Resp(fmt.Sprintf("%s %s", verb, idList(res)))
+}
+
+func idList(ids []string) string {
+	var l = make([]string, len(ids))
+	for i, id := range ids {
+		l[i] = fmt.Sprintf("`%s`", id)
+	}
+	return "[" + strings.Join(l, ", ") + "]"
+}
+
+// Join channel
+type Join struct{ Cmd }
+
+var joinArgs = gateway.MustParseArgSpec("channel:rest")
+
+// Usage of command
+func (c *Join) Usage() string { return joinArgs.Usage() }
+
+// Description of command
+func (c *Join) Description() string { return "Switch to channel" }
+
+// Execute command
+func (c *Join) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := joinArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	j, ok := gw.(goop.ChannelJoiner)
+	if !ok {
+		return t.Resp("Gateway cannot switch channels")
+	}
+
+	switch err := j.JoinChannel(args.Get("channel")); err {
+	case nil:
+		return nil
+	case gateway.ErrNoChannel:
+		return t.Resp("Not connected")
+	default:
+		return err
+	}
+}
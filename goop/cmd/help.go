@@ -0,0 +1,53 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Help lists available commands or describes a single command
+type Help struct{ Cmd }
+
+// Usage of command
+func (c *Help) Usage() string { return "[command]" }
+
+// Description of command
+func (c *Help) Description() string { return "List available commands, or show usage of command" }
+
+// Execute command
+func (c *Help) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	if len(t.Arg) > 0 {
+		var name = strings.ToLower(gateway.TrimTrigger(t.Arg[0], gateway.Triggers(gw)...))
+		var cmd = g.Commands()[name]
+		if cmd == nil || !cmd.CanExecute(t) {
+			return t.Resp(fmt.Sprintf("Unknown command `%s`", name))
+		}
+
+		var syntax = gw.Trigger() + name
+		if u := goop.CommandUsage(cmd); u != "" {
+			syntax += " " + u
+		}
+		if d := goop.CommandDescription(cmd); d != "" {
+			return t.Resp(fmt.Sprintf("`%s` %s", syntax, d))
+		}
+		return t.Resp(fmt.Sprintf("`%s`", syntax))
+	}
+
+	var l = []string{}
+	for name, cmd := range g.Commands() {
+		if cmd.CanExecute(t) {
+			l = append(l, name)
+		}
+	}
+	sort.Strings(l)
+
+	return t.Resp(fmt.Sprintf("Available commands: [%s]", strings.Join(l, ", ")))
+}
@@ -0,0 +1,71 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Highlight manages keyword subscriptions that are forwarded privately when mentioned on any gateway
+type Highlight struct {
+	Cmd
+	Limit int
+}
+
+var highlightArgs = gateway.MustParseArgSpec("action keyword:rest?")
+
+// Usage of command
+func (c *Highlight) Usage() string { return highlightArgs.Usage() }
+
+// Description of command
+func (c *Highlight) Description() string { return "Add, list, or remove highlight keywords" }
+
+// Execute command
+func (c *Highlight) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := highlightArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var keyword = strings.ToLower(strings.TrimSpace(args.Get("keyword")))
+
+	switch strings.ToLower(args.Get("action")) {
+	case "list", "l":
+		var k = g.Highlights(gw, t.User.ID)
+		if len(k) == 0 {
+			return t.Resp("No highlight keywords")
+		}
+		return t.Resp("Highlight keywords: " + strings.Join(k, ", "))
+	case "add", "a":
+		if keyword == "" {
+			return t.Resp("Expected 2 arguments: add [keyword...]")
+		}
+
+		ok, err := g.AddHighlight(gw, &t.User, keyword, c.Limit)
+		switch {
+		case err == goop.ErrHighlightLimit:
+			return t.Resp(fmt.Sprintf("You cannot have more than %d highlight keywords", c.Limit))
+		case err != nil:
+			return err
+		case !ok:
+			return t.Resp(MsgNoChanges)
+		}
+		return t.Resp(fmt.Sprintf("You will be notified when `%s` is mentioned", keyword))
+	case "remove", "rm", "r":
+		if keyword == "" {
+			return t.Resp("Expected 2 arguments: remove [keyword...]")
+		}
+		if !g.RemoveHighlight(gw, t.User.ID, keyword) {
+			return t.Resp(MsgNoChanges)
+		}
+		return t.Resp(fmt.Sprintf("Removed `%s`", keyword))
+	default:
+		return t.Resp("Expected action to be one of add|list|remove")
+	}
+}
@@ -0,0 +1,97 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// History whispers recent chat messages of a gateway
+type History struct {
+	Cmd
+	DefaultNum int
+	MaxNum     int
+}
+
+var historyArgs = gateway.MustParseArgSpec("gateway? num:int?")
+
+// Usage of command
+func (c *History) Usage() string { return historyArgs.Usage() }
+
+// Description of command
+func (c *History) Description() string { return "Whisper recent chat messages" }
+
+// Execute command
+func (c *History) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := historyArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var pat = gw.ID()
+	var num = c.DefaultNum
+	if args.Has("gateway") {
+		// Allow omitting gateway (i.e. `.history 20`)
+		if n, err := strconv.Atoi(args.Get("gateway")); err == nil && !args.Has("num") {
+			num = n
+		} else {
+			pat = args.Get("gateway")
+		}
+	}
+	if args.Has("num") {
+		num = int(args.Int("num"))
+	}
+	if num <= 0 {
+		return t.Resp("Expected positive number of messages")
+	}
+	if c.MaxNum > 0 && num > c.MaxNum {
+		num = c.MaxNum
+	}
+
+	var ids = g.FindGateways(pat)
+	if len(ids) == 0 {
+		return t.Resp("No matching gateway found")
+	}
+
+	var resp = gw.Responder(gw, t.User.ID, true)
+
+	var found = false
+	for _, id := range ids {
+		// Only show messages that are relayed to this gateway
+		var h = []goop.HistoryMessage{}
+		for _, m := range g.History(id, 0) {
+			if g.RelaysChat(gw.ID(), id, m.Access) {
+				h = append(h, m)
+			}
+		}
+		if len(h) == 0 {
+			continue
+		}
+		if len(h) > num {
+			h = h[len(h)-num:]
+		}
+
+		found = true
+		if len(ids) > 1 || id != gw.ID() {
+			if err := resp(fmt.Sprintf("History of %s:", id)); err != nil {
+				return err
+			}
+		}
+		for i := range h {
+			if err := resp(h[i].String()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !found {
+		return t.Resp("No recent messages")
+	}
+	return nil
+}
@@ -15,22 +15,46 @@ import (
 // Kick user
 type Kick struct {
 	Cmd
+	ConfirmConfig
 	AccessProtect  gateway.AccessLevel
 	AccessOverride gateway.AccessLevel
 }
 
+var kickArgs = gateway.MustParseArgSpec("username:chanuser --dry-run")
+
+// Usage of command
+func (c *Kick) Usage() string { return kickArgs.Usage() }
+
+// Description of command
+func (c *Kick) Description() string { return "Kick user from channel" }
+
 // Execute command
 func (c *Kick) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 1 {
-		return t.Resp("Expected 1 argument: [user]")
+	args, err := kickArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
-	var users = gateway.FindUserInChannel(gw, t.Arg[0])
+	var users = args.Users("username")
 
+	if ok, err := c.preview("kick", args.Bool("dry-run"), unprotected(users, t, c.protected), t, gw, g, func() error { return c.kick(t, gw, g, users) }); ok {
+		return err
+	}
+	return c.kick(t, gw, g, users)
+}
+
+func (c *Kick) protected(t *gateway.Trigger, u *gateway.User) bool {
+	return u.ID == t.User.ID || u.Access >= t.User.Access || (u.Access >= c.AccessProtect && t.User.Access < c.AccessOverride)
+}
+
+func (c *Kick) kick(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop, users []*gateway.User) error {
 	var p = 0
 	var l = []string{}
 
+	var undo []goop.ModAction
+	defer func() { g.RecordMod(gw, &t.User, undo) }()
+
 	for _, u := range users {
-		if u.ID == t.User.ID || u.Access >= t.User.Access || (u.Access >= c.AccessProtect && t.User.Access < c.AccessOverride) {
+		if c.protected(t, u) {
 			p++
 			continue
 		}
@@ -38,6 +62,7 @@ func (c *Kick) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) err
 		switch err {
 		case nil:
 			l = append(l, fmt.Sprintf("`%s`", u.Name))
+			undo = append(undo, goop.ModAction{Gateway: gw.ID(), User: *u, Kick: true})
 		case gateway.ErrNoUser:
 			// ignore
 		case gateway.ErrNotImplemented, gateway.ErrNoChannel:
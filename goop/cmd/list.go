@@ -15,30 +15,28 @@ import (
 // List users for a given access level
 type List struct{ Cmd }
 
+var listArgs = gateway.MustParseArgSpec("min-access:access max-access:access?")
+
+// Usage of command
+func (c *List) Usage() string { return listArgs.Usage() }
+
+// Description of command
+func (c *List) Description() string { return "List users with given access level" }
+
 // Execute command
 func (c *List) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 1 {
-		return t.Resp("Expected 1 argument: [access]")
+	args, err := listArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
 
-	var a1 = gateway.AccessDefault
-	var a2 = gateway.AccessDefault
-	if len(t.Arg) == 1 {
-		if err := a1.UnmarshalText([]byte(t.Arg[0])); err != nil {
-			return t.Resp("Expected 1 argument: [access]")
-		}
-		a2 = a1
-	} else {
-		var err = a1.UnmarshalText([]byte(t.Arg[0]))
-		if err == nil {
-			err = a2.UnmarshalText([]byte(t.Arg[1]))
-		}
-		if err != nil {
-			return t.Resp("Expected 2 arguments: [from_access] [to_access]")
-		}
-		if a2 < a1 {
-			a1, a2 = a2, a1
-		}
+	var a1 = args.Access("min-access")
+	var a2 = a1
+	if args.Has("max-access") {
+		a2 = args.Access("max-access")
+	}
+	if a2 < a1 {
+		a1, a2 = a2, a1
 	}
 
 	var users = gw.Users()
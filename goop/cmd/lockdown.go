@@ -0,0 +1,61 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Lockdown kicks joining users and ignores messages from users below access level
+type Lockdown struct {
+	Cmd
+	DefaultAccess   gateway.AccessLevel
+	DefaultDuration time.Duration
+}
+
+var lockdownArgs = gateway.MustParseArgSpec("access:access? duration:duration? --off")
+
+// Usage of command
+func (c *Lockdown) Usage() string { return lockdownArgs.Usage() }
+
+// Description of command
+func (c *Lockdown) Description() string { return "Lock channel for users below access level" }
+
+// Execute command
+func (c *Lockdown) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := lockdownArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	if args.Bool("off") {
+		if !g.StopLockdown(gw) {
+			return t.Resp("No active lockdown")
+		}
+		return nil
+	}
+
+	var access = c.DefaultAccess
+	if args.Has("access") {
+		access = args.Access("access")
+	}
+	if access > t.User.Access {
+		return t.Resp(MsgNoPermission)
+	}
+
+	var d = c.DefaultDuration
+	if args.Has("duration") {
+		d = args.Duration("duration")
+	}
+	if d <= 0 {
+		d = 10 * time.Minute
+	}
+
+	g.StartLockdown(gw, access, d)
+	return nil
+}
@@ -15,12 +15,21 @@ import (
 // Ping user
 type Ping struct{ Cmd }
 
+var pingArgs = gateway.MustParseArgSpec("username:user")
+
+// Usage of command
+func (c *Ping) Usage() string { return pingArgs.Usage() }
+
+// Description of command
+func (c *Ping) Description() string { return "Print ping of user" }
+
 // Execute command
 func (c *Ping) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 1 {
-		return t.Resp("Expected 1 argument: [user]")
+	args, err := pingArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
-	var u = gateway.FindUser(gw, t.Arg[0])
+	var u = args.Users("username")
 	switch len(u) {
 	case 0:
 		return t.Resp(MsgNoUserFound)
@@ -0,0 +1,217 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Points prints the points and karma of a user
+type Points struct{ Cmd }
+
+var pointsArgs = gateway.MustParseArgSpec("username?")
+
+// Usage of command
+func (c *Points) Usage() string { return pointsArgs.Usage() }
+
+// Description of command
+func (c *Points) Description() string { return "Print points and karma of user" }
+
+// Execute command
+func (c *Points) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := pointsArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	if !args.Has("username") {
+		var p = g.Points(gw, &t.User)
+		return t.Resp(fmt.Sprintf("You have %d point(s) and %d karma", p.Points, p.Karma))
+	}
+
+	var res = g.FindPoints(gw.ID(), args.Get("username"))
+	if len(res) == 0 {
+		return t.Resp(MsgNoUserFound)
+	}
+
+	var l = make([]string, len(res))
+	for i, p := range res {
+		l[i] = fmt.Sprintf("`%s` has %d point(s) and %d karma", p.Name, p.Points, p.Karma)
+	}
+	return t.Resp(strings.Join(l, "\n"))
+}
+
+// Top prints the users with the most points or karma
+type Top struct {
+	Cmd
+	MaxResults int
+}
+
+var topArgs = gateway.MustParseArgSpec("num:int? --karma")
+
+// Usage of command
+func (c *Top) Usage() string { return topArgs.Usage() }
+
+// Description of command
+func (c *Top) Description() string { return "Print users with most points or karma" }
+
+// Execute command
+func (c *Top) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := topArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var n = c.MaxResults
+	if args.Has("num") {
+		if i := int(args.Int("num")); i > 0 && i <= n {
+			n = i
+		}
+	}
+
+	var karma = args.Bool("karma")
+	var top = g.PointsTop(n, karma)
+	if len(top) == 0 {
+		return t.Resp(MsgNoUserFound)
+	}
+
+	var l = make([]string, len(top))
+	for i, r := range top {
+		var name = r.Name
+		if gw := g.Gateways()[r.Gateway]; gw != nil {
+			name += "@" + gw.Discriminator()
+		}
+		var v = r.Points
+		if karma {
+			v = r.Karma
+		}
+		l[i] = fmt.Sprintf("%d. %s (%d)", i+1, name, v)
+	}
+	return t.Resp(strings.Join(l, ", "))
+}
+
+// Give transfers points to another user
+type Give struct{ Cmd }
+
+var giveArgs = gateway.MustParseArgSpec("username:user amount:int")
+
+// Usage of command
+func (c *Give) Usage() string { return giveArgs.Usage() }
+
+// Description of command
+func (c *Give) Description() string { return "Give some of your points to user" }
+
+// Execute command
+func (c *Give) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := giveArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var users = args.Users("username")
+	switch {
+	case len(users) == 0:
+		return t.Resp(MsgNoUserFound)
+	case len(users) > 1:
+		return t.Resp("Expected exact username")
+	case users[0].ID == t.User.ID:
+		return t.Resp("You cannot give points to yourself")
+	}
+
+	var n = int(args.Int("amount"))
+	if n <= 0 {
+		return t.Resp("Expected positive amount")
+	}
+
+	left, err := g.GivePoints(gw, &t.User, users[0], n)
+	switch err {
+	case nil:
+		return t.Resp(fmt.Sprintf("Gave %d point(s) to `%s`, %d left", n, users[0].Name, left))
+	case goop.ErrNotEnoughPoints:
+		return t.Resp(fmt.Sprintf("You only have %d point(s)", left))
+	case goop.ErrPointsDisabled:
+		return t.Resp("Points are disabled")
+	default:
+		return err
+	}
+}
+
+// Reward that can be redeemed for points
+type Reward struct {
+	Cost     int
+	Access   gateway.AccessLevel
+	Duration time.Duration
+}
+
+// Redeem exchanges points for a reward
+type Redeem struct {
+	Cmd
+	Rewards map[string]*Reward
+}
+
+var redeemArgs = gateway.MustParseArgSpec("reward?")
+
+// Usage of command
+func (c *Redeem) Usage() string { return redeemArgs.Usage() }
+
+// Description of command
+func (c *Redeem) Description() string { return "Redeem points for a reward" }
+
+// Execute command
+func (c *Redeem) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := redeemArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var r = c.Rewards[strings.ToLower(args.Get("reward"))]
+	if r == nil {
+		var l = make([]string, 0, len(c.Rewards))
+		for k, r := range c.Rewards {
+			if r != nil {
+				l = append(l, fmt.Sprintf("%s <%s> for %s (%d)", k, r.Access, r.Duration, r.Cost))
+			}
+		}
+		if len(l) == 0 {
+			return t.Resp("No rewards available")
+		}
+		sort.Strings(l)
+		return t.Resp("Available rewards: " + strings.Join(l, ", "))
+	}
+
+	var p = g.Points(gw, &t.User)
+	if r.Access <= t.User.Access && (p.Reward == nil || p.Reward.Access != r.Access) {
+		return t.Resp("You already have this access level")
+	}
+
+	left, err := g.AddPoints(gw, &t.User, -r.Cost)
+	switch err {
+	case nil:
+	case goop.ErrNotEnoughPoints:
+		return t.Resp(fmt.Sprintf("You only have %d point(s)", left))
+	case goop.ErrPointsDisabled:
+		return t.Resp("Points are disabled")
+	default:
+		return err
+	}
+
+	if err := g.GrantAccess(gw, &t.User, r.Access, r.Duration); err != nil {
+		// Refund
+		g.AddPoints(gw, &t.User, r.Cost)
+		if err == gateway.ErrNotImplemented {
+			return t.Resp("Rewards are not available on this gateway")
+		}
+		t.Resp(MsgInternalError)
+		return err
+	}
+
+	return t.Resp(fmt.Sprintf("Granted <%s> for %s, %d point(s) left", r.Access, r.Duration, left))
+}
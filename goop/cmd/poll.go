@@ -0,0 +1,134 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Messages
+const (
+	MsgNoPoll = "No active poll"
+)
+
+// Poll starts a poll on all relayed gateways
+type Poll struct {
+	Cmd
+	Duration       time.Duration
+	VoteAccess     gateway.AccessLevel
+	AccessOverride gateway.AccessLevel
+}
+
+var pollArgs = gateway.MustParseArgSpec("question? options:rest? --time:duration --access:access --close")
+
+// Usage of command
+func (c *Poll) Usage() string { return pollArgs.Usage() }
+
+// Description of command
+func (c *Poll) Description() string { return "Start, show, or close a poll" }
+
+// Execute command
+func (c *Poll) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := pollArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	if args.Bool("close") {
+		var p = g.ActivePoll(gw)
+		if p == nil {
+			return t.Resp(MsgNoPoll)
+		}
+		if (p.Origin != gw || p.Creator.ID != t.User.ID) && t.User.Access < c.AccessOverride {
+			return t.Resp(MsgNoPermission)
+		}
+		g.ClosePoll(p)
+		return nil
+	}
+
+	if !args.Has("question") {
+		var p = g.ActivePoll(gw)
+		if p == nil {
+			return t.Resp(MsgNoPoll)
+		}
+		return t.Resp(fmt.Sprintf("%s, closes in %s", p.Result(), time.Until(p.Ends).Round(time.Second)))
+	}
+
+	var opt []string
+	if o := gateway.ExtractTrigger("poll " + args.Get("options")); o != nil {
+		opt = o.Arg
+	}
+
+	var p = goop.Poll{
+		Question: args.Get("question"),
+		Options:  opt,
+		Access:   c.VoteAccess,
+		Origin:   gw,
+		Creator:  t.User,
+	}
+	if args.Has("access") {
+		p.Access = args.Access("access")
+	}
+
+	var d = c.Duration
+	if args.Has("time") {
+		d = args.Duration("time")
+	}
+	if d <= 0 {
+		d = 5 * time.Minute
+	}
+
+	switch err := g.StartPoll(&p, d); err {
+	case nil:
+		return nil
+	case goop.ErrPollOptions:
+		return t.Resp(fmt.Sprintf("Expected 2 to %d options: %s", goop.PollMaxOptions, pollArgs.Usage()))
+	default:
+		return err
+	}
+}
+
+// Vote in active poll
+type Vote struct{ Cmd }
+
+var voteArgs = gateway.MustParseArgSpec("option:int")
+
+// Usage of command
+func (c *Vote) Usage() string { return voteArgs.Usage() }
+
+// Description of command
+func (c *Vote) Description() string { return "Vote in active poll" }
+
+// Execute command
+func (c *Vote) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := voteArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var p = g.ActivePoll(gw)
+	if p == nil {
+		return t.Resp(MsgNoPoll)
+	}
+
+	switch err := p.Vote(gw, &t.User, int(args.Int("option"))-1); err {
+	case nil:
+		return t.Resp(fmt.Sprintf("Voted for `%s`", p.Options[args.Int("option")-1]))
+	case goop.ErrDuplicateVoter:
+		return t.Resp("You already voted")
+	case goop.ErrInvalidOption:
+		return t.Resp(fmt.Sprintf("Expected option between 1 and %d", len(p.Options)))
+	case goop.ErrNoPoll:
+		return t.Resp(MsgNoPoll)
+	case gateway.ErrNoPermission:
+		return t.Resp(MsgNoPermission)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,148 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+func addReminder(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop, to *gateway.User, d time.Duration, msg string, limit int) error {
+	if d <= 0 {
+		return t.Resp("Expected positive duration")
+	}
+
+	var err = g.AddReminder(&goop.Reminder{
+		Gateway:     gw.ID(),
+		To:          to.ID,
+		ToName:      to.Name,
+		From:        t.User.Name,
+		FromID:      t.User.ID,
+		FromGateway: gw.ID(),
+		Message:     msg,
+		Due:         time.Now().Add(d),
+	}, limit)
+
+	switch err {
+	case nil:
+		if to.ID == t.User.ID {
+			return t.Resp(fmt.Sprintf("You will be reminded in %s", d))
+		}
+		return t.Resp(fmt.Sprintf("`%s` will be reminded in %s", to.Name, d))
+	case goop.ErrReminderLimit:
+		return t.Resp(fmt.Sprintf("You cannot have more than %d pending reminders", limit))
+	default:
+		return err
+	}
+}
+
+// RemindMe schedules a reminder for yourself
+type RemindMe struct {
+	Cmd
+	Limit int
+}
+
+var remindMeArgs = gateway.MustParseArgSpec("duration:duration message:rest")
+
+// Usage of command
+func (c *RemindMe) Usage() string { return remindMeArgs.Usage() }
+
+// Description of command
+func (c *RemindMe) Description() string { return "Remind yourself after duration" }
+
+// Execute command
+func (c *RemindMe) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := remindMeArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	return addReminder(t, gw, g, &t.User, args.Duration("duration"), args.Get("message"), c.Limit)
+}
+
+// Remind schedules a reminder for another user
+type Remind struct {
+	Cmd
+	Limit int
+}
+
+var remindArgs = gateway.MustParseArgSpec("username:user duration:duration message:rest")
+
+// Usage of command
+func (c *Remind) Usage() string { return remindArgs.Usage() }
+
+// Description of command
+func (c *Remind) Description() string { return "Remind user after duration" }
+
+// Execute command
+func (c *Remind) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := remindArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var users = args.Users("username")
+	switch {
+	case len(users) == 0:
+		return t.Resp(MsgNoUserFound)
+	case len(users) > 1:
+		return t.Resp("Expected exact username")
+	}
+
+	return addReminder(t, gw, g, users[0], args.Duration("duration"), args.Get("message"), c.Limit)
+}
+
+// Reminders lists or cancels pending reminders
+type Reminders struct{ Cmd }
+
+var remindersArgs = gateway.MustParseArgSpec("action? num:int?")
+
+// Usage of command
+func (c *Reminders) Usage() string { return remindersArgs.Usage() }
+
+// Description of command
+func (c *Reminders) Description() string { return "List or cancel your pending reminders" }
+
+// Execute command
+func (c *Reminders) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := remindersArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	switch strings.ToLower(args.Get("action")) {
+	case "", "list", "l":
+		var p = g.PendingReminders(gw.ID(), t.User.ID)
+		if len(p) == 0 {
+			return t.Resp("No pending reminders")
+		}
+
+		for i, r := range p {
+			var to = ""
+			if r.To != t.User.ID {
+				to = fmt.Sprintf(" for `%s`", r.ToName)
+			}
+			if err := t.Resp(fmt.Sprintf("%d. In %s%s: %s", i+1, time.Until(r.Due).Round(time.Second), to, r.Message)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "cancel", "c", "remove", "rm":
+		if !args.Has("num") {
+			return t.Resp("Expected 2 arguments: cancel [num]")
+		}
+		var r = g.CancelReminder(gw.ID(), t.User.ID, int(args.Int("num")))
+		if r == nil {
+			return t.Resp(MsgNoChanges)
+		}
+		return t.Resp(fmt.Sprintf("Cancelled reminder: %s", r.Message))
+	default:
+		return t.Resp("Expected action to be one of list|cancel")
+	}
+}
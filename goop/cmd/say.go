@@ -14,6 +14,12 @@ import (
 // Echo input
 type Echo struct{ Cmd }
 
+// Usage of command
+func (c *Echo) Usage() string { return "[message...]" }
+
+// Description of command
+func (c *Echo) Description() string { return "Echo message back" }
+
 // Execute command
 func (c *Echo) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	return t.Resp(strings.Join(t.Raw, ""))
@@ -22,6 +28,12 @@ func (c *Echo) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) err
 // Say input in channel
 type Say struct{ Cmd }
 
+// Usage of command
+func (c *Say) Usage() string { return "[message...]" }
+
+// Description of command
+func (c *Say) Description() string { return "Say message in channel" }
+
 // Execute command
 func (c *Say) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	return gw.Say(strings.Join(t.Raw, ""))
@@ -30,18 +42,27 @@ func (c *Say) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) erro
 // SayPrivate forwards input to user in private
 type SayPrivate struct{ Cmd }
 
+var sayPrivateArgs = gateway.MustParseArgSpec("username:user message:rest")
+
+// Usage of command
+func (c *SayPrivate) Usage() string { return sayPrivateArgs.Usage() }
+
+// Description of command
+func (c *SayPrivate) Description() string { return "Whisper message to user" }
+
 // Execute command
 func (c *SayPrivate) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 2 {
-		return t.Resp("Expected 2 arguments: [user] [message]")
+	args, err := sayPrivateArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
-	var u = gateway.FindUser(gw, t.Arg[0])
+	var u = args.Users("username")
 	switch len(u) {
 	case 0:
-		u = []*gateway.User{&gateway.User{ID: t.Arg[0]}}
+		u = []*gateway.User{&gateway.User{ID: args.Get("username")}}
 		fallthrough
 	case 1:
-		if err := gw.SayPrivate(u[0].ID, strings.Join(t.Raw[1:], "")); err != nil && err != gateway.ErrNotImplemented {
+		if err := gw.SayPrivate(u[0].ID, args.Get("message")); err != nil && err != gateway.ErrNotImplemented {
 			t.Resp(MsgInternalError)
 			return err
 		}
@@ -0,0 +1,94 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Schedule manages scheduled triggers
+type Schedule struct{ Cmd }
+
+var scheduleArgs = gateway.MustParseArgSpec("action name? when? gateway? trigger:rest?")
+
+// Usage of command
+func (c *Schedule) Usage() string { return scheduleArgs.Usage() }
+
+// Description of command
+func (c *Schedule) Description() string { return "Add, list, or remove scheduled triggers" }
+
+func parseInterval(s string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// Execute command
+func (c *Schedule) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := scheduleArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	switch strings.ToLower(args.Get("action")) {
+	case "list", "l":
+		var s = g.Schedule()
+		if len(s) == 0 {
+			return t.Resp("No scheduled triggers")
+		}
+
+		var k = make([]string, 0, len(s))
+		for n := range s {
+			k = append(k, n)
+		}
+		sort.Strings(k)
+
+		for _, n := range k {
+			var e = s[n]
+			if err := t.Resp(fmt.Sprintf("%s %s", n, e.String())); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "add", "a":
+		if !args.Has("trigger") {
+			return t.Resp("Expected 5 arguments: add [name] [cron|interval] [gateway] [trigger...]")
+		}
+
+		var e = goop.ScheduleEntry{
+			Gateway: args.Get("gateway"),
+			Trigger: args.Get("trigger"),
+			Access:  t.User.Access,
+		}
+		if d, err := parseInterval(args.Get("when")); err == nil {
+			e.Interval = d
+		} else {
+			e.Cron = args.Get("when")
+		}
+
+		if err := g.AddSchedule(args.Get("name"), &e); err != nil {
+			return t.Resp(err.Error())
+		}
+		return t.Resp(fmt.Sprintf("Scheduled %s %s", strings.ToLower(args.Get("name")), e.String()))
+	case "remove", "rm", "r":
+		if !args.Has("name") {
+			return t.Resp("Expected 2 arguments: remove [name]")
+		}
+		if !g.RemoveSchedule(args.Get("name")) {
+			return t.Resp(MsgNoChanges)
+		}
+		return t.Resp(fmt.Sprintf("Removed %s", strings.ToLower(args.Get("name"))))
+	default:
+		return t.Resp("Expected action to be one of add|list|remove")
+	}
+}
@@ -0,0 +1,95 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Seen prints when user was last seen on any gateway
+type Seen struct {
+	Cmd
+	MaxResults int
+}
+
+var seenArgs = gateway.MustParseArgSpec("username")
+
+// Usage of command
+func (c *Seen) Usage() string { return seenArgs.Usage() }
+
+// Description of command
+func (c *Seen) Description() string { return "Print when user was last seen" }
+
+func online(gw gateway.Gateway, uid string) bool {
+	if gw == nil {
+		return false
+	}
+	for _, u := range gw.ChannelUsers() {
+		if u.ID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute command
+func (c *Seen) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := seenArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	// Only report activity on gateways that relay the user's chat to this gateway
+	var res = []*goop.Seen{}
+	for _, s := range g.FindSeen(args.Get("username")) {
+		if g.RelaysChat(gw.ID(), s.Gateway, s.Access) {
+			res = append(res, s)
+		}
+	}
+	if len(res) == 0 {
+		return t.Resp(MsgNoUserFound)
+	}
+
+	var max = c.MaxResults
+	if max <= 0 {
+		max = 3
+	}
+	if len(res) > max {
+		t.Resp(fmt.Sprintf("Found %d users, showing the %d most recent", len(res), max))
+		res = res[:max]
+	}
+
+	for _, s := range res {
+		var w = g.Gateways()[s.Gateway]
+		var where = s.Gateway
+		if w != nil {
+			where = w.Discriminator()
+		}
+		if s.Channel != "" {
+			where += " in " + s.Channel
+		}
+
+		var msg string
+		if online(w, s.UserID) {
+			msg = fmt.Sprintf("`%s` is online on %s", s.Name, where)
+		} else {
+			msg = fmt.Sprintf("`%s` was last seen %s ago on %s", s.Name, time.Since(s.LastSeen).Round(time.Second), where)
+		}
+		if s.Message != "" {
+			msg += fmt.Sprintf(", saying \"%s\"", s.Message)
+		}
+		msg += fmt.Sprintf(" (first seen %s)", s.FirstSeen.Format("2006-01-02"))
+
+		if err := t.Resp(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -15,33 +15,57 @@ import (
 // Set accesslevel for user
 type Set struct {
 	Cmd
+	ConfirmConfig
 	DefaultAccess gateway.AccessLevel
 }
 
+var setArgs = gateway.MustParseArgSpec("username:user access:access? --dry-run")
+
+// Usage of command
+func (c *Set) Usage() string { return setArgs.Usage() }
+
+// Description of command
+func (c *Set) Description() string { return "Set access level for user" }
+
 // Execute command
 func (c *Set) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 1 {
-		return t.Resp("Expected 1 argument: [user]")
+	args, err := setArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
-	var users = gateway.FindUser(gw, t.Arg[0])
+	var users = args.Users("username")
 	if len(users) == 0 {
-		users = []*gateway.User{&gateway.User{ID: t.Arg[0], Name: t.Arg[0]}}
+		users = []*gateway.User{&gateway.User{ID: args.Get("username"), Name: args.Get("username")}}
 	}
 
 	var access = c.DefaultAccess
-	if len(t.Arg) > 1 {
-		if err := access.UnmarshalText([]byte(t.Arg[1])); err != nil {
-			return t.Resp("Expected 2 arguments: [user] [access]")
-		}
+	if args.Has("access") {
+		access = args.Access("access")
 	}
 
 	if access >= t.User.Access {
 		return t.Resp("You cannot grant this access level")
 	}
 
+	var verb = fmt.Sprintf("set <%s> for", access.String())
+	if ok, err := c.preview(verb, args.Bool("dry-run"), unprotected(users, t, c.protected), t, gw, g, func() error { return c.set(t, gw, g, users, access) }); ok {
+		return err
+	}
+	return c.set(t, gw, g, users, access)
+}
+
+func (c *Set) protected(t *gateway.Trigger, u *gateway.User) bool {
+	return u.ID == t.User.ID || u.Access >= t.User.Access
+}
+
+func (c *Set) set(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop, users []*gateway.User, access gateway.AccessLevel) error {
 	var l = []string{}
+
+	var undo []goop.ModAction
+	defer func() { g.RecordMod(gw, &t.User, undo) }()
+
 	for _, u := range users {
-		if u.ID == t.User.ID || u.Access == access || u.Access >= t.User.Access {
+		if u.Access == access || c.protected(t, u) {
 			continue
 		}
 		prev, err := gw.SetUserAccess(u.ID, access)
@@ -52,6 +76,7 @@ func (c *Set) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) erro
 				action = "Demoted"
 			}
 			l = append(l, fmt.Sprintf("%s `%s` from <%s> to <%s>", action, u.Name, prev.String(), access.String()))
+			undo = append(undo, goop.ModAction{Gateway: gw.ID(), User: *u, Prev: prev})
 		case gateway.ErrNotImplemented:
 			return nil
 		default:
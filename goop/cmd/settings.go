@@ -86,6 +86,12 @@ func str(v interface{}) string {
 	}
 }
 
+// Usage of command
+func (c *Settings) Usage() string { return "[action] [key] [new_value]" }
+
+// Description of command
+func (c *Settings) Description() string { return "Find, get, set, or unset configuration" }
+
 // Execute command
 func (c *Settings) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	// Always respond in private
@@ -95,7 +101,10 @@ func (c *Settings) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop)
 		return resp("Expected 2 arguments: find|get|set|unset [setting]")
 	}
 
+	g.ConfigMut.Lock()
 	var m = g.Config.FlatMap()
+	g.ConfigMut.Unlock()
+
 	var l = []string{}
 	var u = false
 
@@ -113,7 +122,9 @@ func (c *Settings) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop)
 	case "unset", "u", "us":
 		var k = matchKeys(m, t.Arg[1])
 		for _, v := range k {
+			g.ConfigMut.Lock()
 			err := g.Config.Unset(v)
+			g.ConfigMut.Unlock()
 			if err != nil {
 				if len(k) == 1 {
 					return resp(err.Error())
@@ -134,7 +145,9 @@ func (c *Settings) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop)
 			k = []string{t.Arg[1]}
 		}
 		for _, v := range k {
+			g.ConfigMut.Lock()
 			err := g.Config.SetString(v, s)
+			g.ConfigMut.Unlock()
 			if err != nil {
 				if len(k) == 1 {
 					return resp(err.Error())
@@ -0,0 +1,120 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Tell leaves a message for a user that is delivered the next time they are seen
+type Tell struct {
+	Cmd
+	Limit int
+}
+
+var tellArgs = gateway.MustParseArgSpec("username message:rest")
+
+// Usage of command
+func (c *Tell) Usage() string { return tellArgs.Usage() }
+
+// Description of command
+func (c *Tell) Description() string { return "Leave a message for user" }
+
+// Execute command
+func (c *Tell) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := tellArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var to = args.Get("username")
+	if strings.ContainsAny(to, "*?[") {
+		return t.Resp("Expected exact username")
+	}
+
+	// Resolve recipient if they are known, otherwise deliver by name
+	var uid string
+	for _, u := range gateway.FindUser(gw, to) {
+		if strings.EqualFold(u.Name, to) || u.ID == to {
+			uid = u.ID
+			to = u.Name
+			break
+		}
+	}
+
+	err = g.SendMail(&goop.Mail{
+		Gateway:     gw.ID(),
+		To:          to,
+		ToID:        uid,
+		From:        t.User.Name,
+		FromID:      t.User.ID,
+		FromGateway: gw.ID(),
+		FromAccess:  t.User.Access,
+		Message:     args.Get("message"),
+	}, c.Limit)
+
+	switch err {
+	case nil:
+		return t.Resp(fmt.Sprintf("Message for `%s` will be delivered when they are seen", to))
+	case goop.ErrMailLimit:
+		return t.Resp(fmt.Sprintf("You cannot have more than %d pending messages", c.Limit))
+	default:
+		return err
+	}
+}
+
+// Inbox lists pending messages left with tell
+type Inbox struct{ Cmd }
+
+// Usage of command
+func (c *Inbox) Usage() string { return "" }
+
+// Description of command
+func (c *Inbox) Description() string { return "List your undelivered messages" }
+
+// Execute command
+func (c *Inbox) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	var mail = g.PendingMail(gw.ID(), t.User.ID)
+	if len(mail) == 0 {
+		return t.Resp("No pending messages")
+	}
+
+	for _, m := range mail {
+		if err := t.Resp(fmt.Sprintf("To `%s` (%s ago): %s", m.To, time.Since(m.Sent).Round(time.Second), m.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Clear deletes pending messages left with tell
+type Clear struct{ Cmd }
+
+var clearArgs = gateway.MustParseArgSpec("username?")
+
+// Usage of command
+func (c *Clear) Usage() string { return clearArgs.Usage() }
+
+// Description of command
+func (c *Clear) Description() string { return "Delete your undelivered messages" }
+
+// Execute command
+func (c *Clear) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := clearArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var n = g.ClearMail(gw.ID(), t.User.ID, args.Get("username"))
+	if n == 0 {
+		return t.Resp(MsgNoChanges)
+	}
+	return t.Resp(fmt.Sprintf("Deleted %d message(s)", n))
+}
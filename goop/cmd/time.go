@@ -17,6 +17,12 @@ type Time struct {
 	Format string
 }
 
+// Usage of command
+func (c *Time) Usage() string { return "" }
+
+// Description of command
+func (c *Time) Description() string { return "Print current time" }
+
 // Execute command
 func (c *Time) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	return t.Resp(time.Now().Format(c.Format))
@@ -27,6 +33,12 @@ type Uptime struct{ Cmd }
 
 var ts = time.Now()
 
+// Usage of command
+func (c *Uptime) Usage() string { return "" }
+
+// Description of command
+func (c *Uptime) Description() string { return "Print time since start" }
+
 // Execute command
 func (c *Uptime) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	return t.Resp("Uptime: " + time.Since(ts).Round(time.Second).String())
@@ -5,6 +5,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/nielsAD/goop/gateway"
 	"github.com/nielsAD/goop/goop"
 )
@@ -12,7 +14,13 @@ import (
 // Trigger outputs the command trigger for gateway
 type Trigger struct{ Cmd }
 
+// Usage of command
+func (c *Trigger) Usage() string { return "" }
+
+// Description of command
+func (c *Trigger) Description() string { return "Print command triggers" }
+
 // Execute command
 func (c *Trigger) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	return t.Resp(gw.Trigger())
+	return t.Resp(strings.Join(gateway.Triggers(gw), " "))
 }
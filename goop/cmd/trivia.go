@@ -0,0 +1,140 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Trivia starts, stops, or prints the scoreboard of a trivia game
+type Trivia struct {
+	Cmd
+	Packs         []string
+	Questions     int
+	Timeout       time.Duration
+	Hints         int
+	MaxResults    int
+	AccessControl gateway.AccessLevel
+}
+
+var triviaArgs = gateway.MustParseArgSpec("action arg? --questions:int")
+
+// Usage of command
+func (c *Trivia) Usage() string { return triviaArgs.Usage() }
+
+// Description of command
+func (c *Trivia) Description() string { return "Start, stop, or print top scores of trivia" }
+
+// questions loads all packs matching pattern pat
+func (c *Trivia) questions(pat string) ([]goop.TriviaQuestion, error) {
+	var res []goop.TriviaQuestion
+	for _, p := range c.Packs {
+		files, err := filepath.Glob(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			var name = strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+			if m, err := filepath.Match(pat, strings.ToLower(name)); err != nil || !m {
+				continue
+			}
+
+			q, err := goop.LoadTriviaPack(f)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", f, err.Error())
+			}
+			res = append(res, q...)
+		}
+	}
+	return res, nil
+}
+
+// Execute command
+func (c *Trivia) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := triviaArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	switch strings.ToLower(args.Get("action")) {
+	case "start":
+		if t.User.Access < c.AccessControl {
+			return t.Resp(MsgNoPermission)
+		}
+
+		var pat = "*"
+		if args.Has("arg") {
+			pat = strings.ToLower(args.Get("arg"))
+		}
+
+		q, err := c.questions(pat)
+		if err != nil {
+			return t.Resp(err.Error())
+		}
+
+		var n = c.Questions
+		if args.Has("questions") {
+			n = int(args.Int("questions"))
+		}
+
+		var game = goop.Trivia{
+			Origin:    gw,
+			Questions: q,
+			Count:     n,
+			Timeout:   c.Timeout,
+			Hints:     c.Hints,
+		}
+
+		switch err := g.StartTrivia(&game); err {
+		case nil:
+			return nil
+		case goop.ErrNoQuestions:
+			return t.Resp("No trivia questions found")
+		case goop.ErrTriviaRunning:
+			return t.Resp("Trivia already running")
+		default:
+			return err
+		}
+	case "stop":
+		if t.User.Access < c.AccessControl {
+			return t.Resp(MsgNoPermission)
+		}
+		if err := g.StopTrivia(gw); err == goop.ErrNoTrivia {
+			return t.Resp("No active trivia")
+		}
+		return nil
+	case "top":
+		var n = c.MaxResults
+		if args.Has("arg") {
+			if i, err := strconv.Atoi(args.Get("arg")); err == nil && i > 0 && i <= n {
+				n = i
+			}
+		}
+
+		var top = g.TriviaTop(n)
+		if len(top) == 0 {
+			return t.Resp("No trivia scores yet")
+		}
+
+		var l = make([]string, len(top))
+		for i, r := range top {
+			var name = r.Name
+			if gw := g.Gateways()[r.Gateway]; gw != nil {
+				name += "@" + gw.Discriminator()
+			}
+			l[i] = fmt.Sprintf("%d. %s (%d)", i+1, name, r.Points)
+		}
+		return t.Resp("Trivia top: " + strings.Join(l, ", "))
+	default:
+		return t.Resp("Expected action to be one of start|stop|top")
+	}
+}
@@ -0,0 +1,66 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+// Undo reverts the last moderation commands (ban, unban, kick, set) of user
+type Undo struct{ Cmd }
+
+var undoArgs = gateway.MustParseArgSpec("num:int=1")
+
+// Usage of command
+func (c *Undo) Usage() string { return undoArgs.Usage() }
+
+// Description of command
+func (c *Undo) Description() string { return "Revert your last moderation commands" }
+
+// Execute command
+func (c *Undo) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	args, err := undoArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+
+	var n = int(args.Int("num"))
+	if n < 1 {
+		return t.Resp("Expected positive number")
+	}
+
+	res, err := g.UndoMod(gw, &t.User, n)
+	if err == goop.ErrNothingToUndo {
+		return t.Resp("Nothing to undo")
+	}
+
+	var l = []string{}
+	for _, a := range res {
+		var s []string
+		switch {
+		case a.Ban:
+			s = append(s, "unbanned")
+		case a.Unban:
+			s = append(s, "banned")
+		}
+		if a.Prev != nil {
+			s = append(s, fmt.Sprintf("restored <%s>", a.Prev.String()))
+		}
+		l = append(l, fmt.Sprintf("`%s` %s", a.User.Name, strings.Join(s, " and ")))
+	}
+
+	if err != nil {
+		t.Resp(MsgInternalError)
+		return err
+	}
+	if len(l) == 0 {
+		return t.Resp(MsgNoChanges)
+	}
+	return t.Resp("Reverted " + strings.Join(l, ", "))
+}
@@ -16,10 +16,16 @@ import (
 // Where prints connected gateways
 type Where struct{ Cmd }
 
+// Usage of command
+func (c *Where) Usage() string { return "" }
+
+// Description of command
+func (c *Where) Description() string { return "Print connected channels" }
+
 // Execute command
 func (c *Where) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	var channels = []string{}
-	for _, gw := range g.Gateways {
+	for _, gw := range g.Gateways() {
 		var c = gw.Channel()
 		if c == nil {
 			continue
@@ -69,11 +75,17 @@ func (l *whoList) Less(i, j int) bool {
 	return l.access[i] > l.access[j]
 }
 
+// Usage of command
+func (c *Who) Usage() string { return "" }
+
+// Description of command
+func (c *Who) Description() string { return "Print users in channel" }
+
 // Execute command
 func (c *Who) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	var total = 0
 	var list whoList
-	for _, gw := range g.Gateways {
+	for _, gw := range g.Gateways() {
 		var users = gw.ChannelUsers()
 		if users == nil {
 			continue
@@ -18,12 +18,21 @@ func userToString(u *gateway.User, gw gateway.Gateway) string {
 // Whois displays user info
 type Whois struct{ Cmd }
 
+var whoisArgs = gateway.MustParseArgSpec("username:user")
+
+// Usage of command
+func (c *Whois) Usage() string { return whoisArgs.Usage() }
+
+// Description of command
+func (c *Whois) Description() string { return "Display user info" }
+
 // Execute command
 func (c *Whois) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
-	if len(t.Arg) < 1 {
-		return t.Resp("Expected 1 argument: [user]")
+	args, err := whoisArgs.Parse(t, gw)
+	if err != nil {
+		return t.Resp(err.Error())
 	}
-	var u = gateway.FindUser(gw, t.Arg[0])
+	var u = args.Users("username")
 	switch len(u) {
 	case 0:
 		return t.Resp(MsgNoUserFound)
@@ -37,6 +46,12 @@ func (c *Whois) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) er
 // Whoami displays user info
 type Whoami struct{ Cmd }
 
+// Usage of command
+func (c *Whoami) Usage() string { return "" }
+
+// Description of command
+func (c *Whoami) Description() string { return "Display your user info" }
+
 // Execute command
 func (c *Whoami) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	return t.Resp(userToString(&t.User, gw))
@@ -0,0 +1,64 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+)
+
+// Errors
+var (
+	ErrNothingToConfirm = errors.New("goop: Nothing to confirm")
+)
+
+type pendingConfirm struct {
+	action  func() error
+	expires time.Time
+}
+
+type confirms struct {
+	mut sync.Mutex
+	ops map[string]pendingConfirm
+}
+
+// RequestConfirm stores action until op confirms it on gw (replacing any previous action), or timeout expires
+func (g *Goop) RequestConfirm(gw gateway.Gateway, op *gateway.User, timeout time.Duration, action func() error) {
+	g.confirms.mut.Lock()
+	defer g.confirms.mut.Unlock()
+
+	var now = time.Now()
+	if g.confirms.ops == nil {
+		g.confirms.ops = make(map[string]pendingConfirm)
+	}
+	for k, p := range g.confirms.ops {
+		if now.After(p.expires) {
+			delete(g.confirms.ops, k)
+		}
+	}
+
+	g.confirms.ops[gw.ID()+gateway.Delimiter+op.ID] = pendingConfirm{
+		action:  action,
+		expires: now.Add(timeout),
+	}
+}
+
+// Confirm executes the pending action of op on gw
+func (g *Goop) Confirm(gw gateway.Gateway, op *gateway.User) error {
+	var key = gw.ID() + gateway.Delimiter + op.ID
+
+	g.confirms.mut.Lock()
+	var p, ok = g.confirms.ops[key]
+	delete(g.confirms.ops, key)
+	g.confirms.mut.Unlock()
+
+	if !ok || time.Now().After(p.expires) {
+		return ErrNothingToConfirm
+	}
+	return p.action()
+}
@@ -0,0 +1,132 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+)
+
+// CooldownConfig stores the global command cooldown settings
+type CooldownConfig struct {
+	BypassAccess gateway.AccessLevel
+	Reply        bool
+}
+
+// CommandCooldown is optionally implemented by commands that limit how often they can be executed
+type CommandCooldown interface {
+	Cooldowns() (user time.Duration, global time.Duration)
+}
+
+type cooldownKey struct {
+	gw  string
+	uid string
+	cmd string
+}
+
+// cooldowns tracks until when a command is unavailable per gateway and user
+// Global cooldowns are stored with an empty user ID
+type cooldowns struct {
+	mut sync.Mutex
+	end map[cooldownKey]time.Time
+}
+
+// Prune expired entries when map reaches this size
+const cooldownPrune = 1024
+
+// check returns the remaining cooldown, or starts a new cooldown period if there is none
+// The returned func cancels the started cooldown period
+func (c *cooldowns) check(gw string, uid string, cmd string, user time.Duration, global time.Duration) (time.Duration, func()) {
+	var now = time.Now()
+	var ku = cooldownKey{gw: gw, uid: uid, cmd: cmd}
+	var kg = cooldownKey{gw: gw, cmd: cmd}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var wait = c.end[ku].Sub(now)
+	if w := c.end[kg].Sub(now); w > wait {
+		wait = w
+	}
+	if wait > 0 {
+		return wait, nil
+	}
+
+	if c.end == nil {
+		c.end = make(map[cooldownKey]time.Time)
+	}
+	if len(c.end) >= cooldownPrune {
+		for k, t := range c.end {
+			if !t.After(now) {
+				delete(c.end, k)
+			}
+		}
+	}
+
+	if user > 0 {
+		c.end[ku] = now.Add(user)
+	}
+	if global > 0 {
+		c.end[kg] = now.Add(global)
+	}
+
+	var cancel = func() {
+		c.mut.Lock()
+		defer c.mut.Unlock()
+
+		// Leave periods that were started afterwards alone
+		if user > 0 && c.end[ku].Equal(now.Add(user)) {
+			delete(c.end, ku)
+		}
+		if global > 0 && c.end[kg].Equal(now.Add(global)) {
+			delete(c.end, kg)
+		}
+	}
+
+	return 0, cancel
+}
+
+func nop() {}
+
+// checkCooldown returns true if command c is not on cooldown for t
+// The returned func cancels the cooldown period (i.e. if t turns out to be invalid)
+func (g *Goop) checkCooldown(name string, c Command, gw gateway.Gateway, t *gateway.Trigger) (bool, func()) {
+	cc, ok := c.(CommandCooldown)
+	if !ok {
+		return true, nop
+	}
+
+	user, global := cc.Cooldowns()
+	if user <= 0 && global <= 0 {
+		return true, nop
+	}
+
+	var conf *CooldownConfig
+	if g.Config != nil {
+		conf = g.Config.GetCooldown()
+	}
+	if conf != nil && t.User.Access >= conf.BypassAccess {
+		return true, nop
+	}
+
+	var wait, cancel = g.cooldowns.check(gw.ID(), t.User.ID, name, user, global)
+	if wait <= 0 {
+		return true, cancel
+	}
+
+	if conf != nil && conf.Reply {
+		var resp = t.Resp
+		go func() {
+			var s = int(math.Ceil(wait.Seconds()))
+			resp(fmt.Sprintf("Try again in %ds", s))
+		}()
+	}
+
+	return false, nop
+}
@@ -0,0 +1,127 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors
+var (
+	ErrInvalidCron = errors.New("goop: Invalid cron expression")
+)
+
+// Cron schedule, matches time by minute, hour, day of month, month, and day of week
+type Cron struct {
+	fields [5]uint64
+	anyDom bool
+	anyDow bool
+}
+
+var cronBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+func parseCronField(s string, min int, max int) (uint64, error) {
+	var res uint64
+	for _, p := range strings.Split(s, ",") {
+		var step = 1
+		if i := strings.IndexByte(p, '/'); i >= 0 {
+			n, err := strconv.Atoi(p[i+1:])
+			if err != nil || n <= 0 {
+				return 0, ErrInvalidCron
+			}
+			step = n
+			p = p[:i]
+		}
+
+		var lo, hi = min, max
+		if p != "*" {
+			var err error
+			var r = strings.SplitN(p, "-", 2)
+			if lo, err = strconv.Atoi(r[0]); err != nil {
+				return 0, ErrInvalidCron
+			}
+			hi = lo
+			if len(r) > 1 {
+				if hi, err = strconv.Atoi(r[1]); err != nil {
+					return 0, ErrInvalidCron
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, ErrInvalidCron
+		}
+
+		for i := lo; i <= hi; i += step {
+			res |= 1 << uint(i)
+		}
+	}
+	return res, nil
+}
+
+// ParseCron parses a standard cron expression (i.e. `*/15 9-17 * * 1-5` or `@hourly`)
+func ParseCron(s string) (*Cron, error) {
+	if m, ok := cronMacros[strings.ToLower(strings.TrimSpace(s))]; ok {
+		s = m
+	}
+
+	var f = strings.Fields(s)
+	if len(f) != len(cronBounds) {
+		return nil, ErrInvalidCron
+	}
+
+	var res = Cron{
+		anyDom: f[2] == "*",
+		anyDow: f[4] == "*",
+	}
+	for i := range f {
+		v, err := parseCronField(f[i], cronBounds[i][0], cronBounds[i][1])
+		if err != nil {
+			return nil, err
+		}
+		res.fields[i] = v
+	}
+
+	// Sunday is both 0 and 7
+	if res.fields[4]&(1<<7) != 0 {
+		res.fields[4] |= 1
+	}
+
+	return &res, nil
+}
+
+// Match returns true if t is within a scheduled minute
+func (c *Cron) Match(t time.Time) bool {
+	var has = func(i int, v int) bool { return c.fields[i]&(1<<uint(v)) != 0 }
+	if !has(0, t.Minute()) || !has(1, t.Hour()) || !has(3, int(t.Month())) {
+		return false
+	}
+
+	var dom = has(2, t.Day())
+	var dow = has(4, int(t.Weekday()))
+	switch {
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	default:
+		// Either day field matches if both are restricted
+		return dom || dow
+	}
+}
@@ -0,0 +1,43 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestCron(t *testing.T) {
+	// Monday
+	var ts = time.Date(2021, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	var cases = map[string]bool{
+		"* * * * *":         true,
+		"30 9 * * *":        true,
+		"*/15 9-17 * * 1-5": true,
+		"*/7 * * * *":       false,
+		"0,30 9 1 * 0":      true,
+		"30 9 2 * 0,7":      false,
+		"@hourly":           false,
+		"30 9 * 2 *":        false,
+	}
+	for s, m := range cases {
+		c, err := goop.ParseCron(s)
+		if err != nil {
+			t.Fatal(s, err)
+		}
+		if c.Match(ts) != m {
+			t.Fatalf("%s: expected match=%v", s, m)
+		}
+	}
+
+	for _, s := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := goop.ParseCron(s); err == nil {
+			t.Fatalf("Expected error for `%s`", s)
+		}
+	}
+}
@@ -19,8 +19,19 @@ type NewGateway struct {
 	gateway.Gateway
 }
 
+// RemovedGateway event
+type RemovedGateway struct {
+	gateway.Gateway
+}
+
 // NewCommand event
 type NewCommand struct {
 	Name    string
 	Command Command
 }
+
+// RemovedCommand event
+type RemovedCommand struct {
+	Name    string
+	Command Command
+}
@@ -0,0 +1,97 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+var errTestCommand = errors.New("test: Command failed")
+
+type failCommand struct{}
+
+func (c *failCommand) CanExecute(t *gateway.Trigger) bool { return true }
+func (c *failCommand) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	return errTestCommand
+}
+
+func TestExecGatewayErrors(t *testing.T) {
+	var g = goop.New(&testConfig{})
+	for _, id := range []string{"test:1", "test:2", "test:3"} {
+		if err := g.AddGateway(id, newTestGateway()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.AddCommand("fail", &failCommand{})
+
+	var n int32
+	g.On(&network.AsyncError{}, func(ev *network.Event) {
+		if ev.Arg.(*network.AsyncError).Err == errTestCommand {
+			atomic.AddInt32(&n, 1)
+		}
+	})
+
+	var trig = &gateway.Trigger{User: gateway.User{ID: "op", Access: gateway.AccessOwner}, Cmd: "test:fail", Resp: func(s string) error { return nil }}
+	ok, err := g.Exec(trig, g.Gateways()["test:1"])
+	if !ok || err != errTestCommand {
+		t.Fatalf("Expected command to fail, got %v %v", ok, err)
+	}
+	if n != 2 {
+		t.Fatalf("Expected the other 2 errors to be reported, got %d", n)
+	}
+}
+
+type cooldownCommand struct{ n int }
+
+var cooldownArgs = gateway.MustParseArgSpec("num:int")
+
+func (c *cooldownCommand) Cooldowns() (time.Duration, time.Duration) { return time.Hour, 0 }
+func (c *cooldownCommand) CanExecute(t *gateway.Trigger) bool        { return true }
+func (c *cooldownCommand) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	if _, err := cooldownArgs.Parse(t, gw); err != nil {
+		return t.Resp(err.Error())
+	}
+	c.n++
+	return nil
+}
+
+func TestExecCooldownUsage(t *testing.T) {
+	var g = goop.New(&testConfig{Cooldown: goop.CooldownConfig{BypassAccess: gateway.AccessOwner}})
+	var gw = newTestGateway()
+	if err := g.AddGateway("test", gw); err != nil {
+		t.Fatal(err)
+	}
+
+	var c cooldownCommand
+	g.AddCommand("cmd", &c)
+
+	var exec = func(arg string) bool {
+		var trig = gateway.ExtractTrigger("cmd " + arg)
+		trig.User = gateway.User{ID: "bob", Access: gateway.AccessVoice}
+		trig.Resp = func(s string) error { return nil }
+		ok, err := g.Exec(trig, gw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok
+	}
+
+	if !exec("typo") || c.n != 0 {
+		t.Fatal("Expected command with invalid arguments to be executed")
+	}
+	if !exec("1") || c.n != 1 {
+		t.Fatal("Expected invalid arguments to not count towards cooldown")
+	}
+	if exec("2") || c.n != 1 {
+		t.Fatal("Expected command to be on cooldown")
+	}
+}
@@ -5,12 +5,12 @@
 package goop
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/nielsAD/goop/gateway"
 	"github.com/nielsAD/gowarcraft3/network"
@@ -20,11 +20,28 @@ import (
 var (
 	ErrDuplicateGateway = errors.New("goop: Duplicate gateway")
 	ErrDuplicateCommand = errors.New("goop: Duplicate command")
+	ErrUnknownCommand   = errors.New("goop: Unknown command")
+	ErrNoFactory        = errors.New("goop: No gateway factory")
+	ErrExecDepth        = errors.New("goop: Maximum command depth exceeded")
 )
 
+// MaxExecDepth limits how deep commands can execute other commands (i.e. recursive aliases)
+const MaxExecDepth = 8
+
 // Config interface
 type Config interface {
 	GetRelay(to, from string) *RelayConfig
+	GetCooldown() *CooldownConfig
+	GetSeen() *SeenConfig
+	GetMail() *MailConfig
+	GetSchedule() *ScheduleConfig
+	GetTrivia() *TriviaConfig
+	GetPoints() *PointsConfig
+	GetHighlight() *HighlightConfig
+	GetHistory() *HistoryConfig
+	GetAway() *AwayConfig
+	GetReminder() *ReminderConfig
+	GetIdentity() *IdentityConfig
 
 	Map() map[string]interface{}
 	FlatMap() map[string]interface{}
@@ -41,57 +58,186 @@ type Command interface {
 	Execute(t *gateway.Trigger, gw gateway.Gateway, g *Goop) error
 }
 
+// CommandInfo is optionally implemented by commands to provide help text
+type CommandInfo interface {
+	Usage() string
+	Description() string
+}
+
+// CommandUsage returns the argument syntax of c, if available
+func CommandUsage(c Command) string {
+	if i, ok := c.(CommandInfo); ok {
+		return i.Usage()
+	}
+	return ""
+}
+
+// CommandDescription returns the description of c, if available
+func CommandDescription(c Command) string {
+	if i, ok := c.(CommandInfo); ok {
+		return i.Description()
+	}
+	return ""
+}
+
+// CommandTriggerOverride is optionally implemented by commands that can be executed with their own triggers
+// in addition to the gateway triggers (an empty trigger allows executing the command without prefix)
+type CommandTriggerOverride interface {
+	TriggerOverride() []string
+}
+
+// CommandSetter is implemented by gateways that register commands natively (i.e. Discord slash commands)
+type CommandSetter interface {
+	SetCommands(cmds map[string]string)
+}
+
+// GatewayFactory creates gateway id from configuration and adds it (and its sub gateways) to g
+// Returns the IDs of all added gateways
+type GatewayFactory func(g *Goop, id string) ([]string, error)
+
 // Goop main
 type Goop struct {
 	network.EventEmitter
 
 	// Read-only
-	Commands map[string]Command
-	Gateways map[string]gateway.Gateway
-	Relay    map[string]map[string]*Relay
-	Config   Config
+	Config  Config
+	Factory GatewayFactory
+
+	// Guards runtime state that is stored in Config
+	ConfigMut sync.Mutex
+
+	cooldowns  cooldowns
+	polls      polls
+	modHistory modHistory
+	confirms   confirms
+	lockdowns  lockdowns
+	trivias    trivias
+	highlights highlights
+	histories  histories
+	aways      aways
+	runners    runners
+
+	cmdmut   sync.Mutex
+	commands atomic.Value
+
+	gwmut    sync.Mutex
+	gateways atomic.Value
+	relay    atomic.Value
+	handlers map[string][]network.EventID
 }
 
 // New initializes a Goop struct
 func New(conf Config) *Goop {
 	var res = &Goop{
-		Commands: map[string]Command{},
-		Gateways: map[string]gateway.Gateway{},
-		Relay:    map[string]map[string]*Relay{},
-		Config:   conf,
+		Config: conf,
 	}
+	res.commands.Store(map[string]Command{})
+	res.gateways.Store(map[string]gateway.Gateway{})
+	res.relay.Store(map[string]map[string]*Relay{})
+
+	// Called before relay handlers, so that messages are dropped everywhere
+	res.On(&gateway.Join{}, res.lockdownJoin)
+	res.On(&gateway.Chat{}, res.lockdownChat)
+
+	// Clear away status before the message is handled as a trigger
+	res.On(&gateway.Chat{}, res.onAway)
 
 	return res
 }
 
+// Commands returns all commands by name, the returned map must not be modified
+func (g *Goop) Commands() map[string]Command {
+	var res, _ = g.commands.Load().(map[string]Command)
+	return res
+}
+
+// Gateways returns all gateways by ID, the returned map must not be modified
+func (g *Goop) Gateways() map[string]gateway.Gateway {
+	var res, _ = g.gateways.Load().(map[string]gateway.Gateway)
+	return res
+}
+
+// Relay returns all relays by target and source gateway ID, the returned map must not be modified
+func (g *Goop) Relay() map[string]map[string]*Relay {
+	var res, _ = g.relay.Load().(map[string]map[string]*Relay)
+	return res
+}
+
+// RelaysChat returns true if chat messages of users with access level a are relayed from one gateway to another
+func (g *Goop) RelaysChat(to, from string, a gateway.AccessLevel) bool {
+	if to == from {
+		return true
+	}
+	var r = g.Relay()[to][from]
+	return r != nil && r.Chat && a >= r.ChatAccess
+}
+
 // AddGateway to goop
 func (g *Goop) AddGateway(id string, gw gateway.Gateway) error {
-	if g.Gateways[id] != nil {
+	g.gwmut.Lock()
+	var prevGws = g.Gateways()
+	if prevGws[id] != nil {
+		g.gwmut.Unlock()
 		return ErrDuplicateGateway
 	}
 
 	gw.SetID(id)
 
-	g.Gateways[id] = gw
-	g.Relay[id] = make(map[string]*Relay)
+	// Copy on write, gateways may be added while running
+	var gws = make(map[string]gateway.Gateway, len(prevGws)+1)
+	for k, v := range prevGws {
+		gws[k] = v
+	}
+	gws[id] = gw
+
+	var prevRel = g.Relay()
+	var rel = make(map[string]map[string]*Relay, len(prevRel)+1)
+	for k, v := range prevRel {
+		rel[k] = make(map[string]*Relay, len(v)+1)
+		for kk, vv := range v {
+			rel[k][kk] = vv
+		}
+	}
+	rel[id] = make(map[string]*Relay)
 
 	// These handlers are called after relay handlers
-	gw.On(&gateway.Chat{}, checkTriggerChat)
-	gw.On(&gateway.PrivateChat{}, checkTriggerPrivateChat)
-
-	gw.On(&gateway.Trigger{}, g.execTrigger)
-	gw.On(&gateway.Chat{}, g.autoKickChat)
-	gw.On(&gateway.Join{}, g.autoKickJoin)
+	var h = []network.EventID{
+		gw.On(&gateway.Chat{}, g.checkTriggerChat),
+		gw.On(&gateway.PrivateChat{}, g.checkTriggerPrivateChat),
+
+		gw.On(&gateway.Trigger{}, g.execTrigger),
+		gw.On(&gateway.Chat{}, g.autoKickChat),
+		gw.On(&gateway.Join{}, g.autoKickJoin),
+
+		gw.On(&gateway.Join{}, g.onSeen),
+		gw.On(&gateway.Leave{}, g.onSeen),
+		gw.On(&gateway.Chat{}, g.onSeen),
+		gw.On(&gateway.PrivateChat{}, g.onSeen),
+
+		gw.On(&gateway.Join{}, g.onMail),
+		gw.On(&gateway.User{}, g.onMail),
+		gw.On(&gateway.Chat{}, g.onMail),
+		gw.On(&gateway.PrivateChat{}, g.onMail),
+
+		gw.On(&gateway.Chat{}, g.onTrivia),
+		gw.On(&gateway.Chat{}, g.onPoints),
+		gw.On(&gateway.Chat{}, g.onHighlight),
+
+		gw.On(&gateway.Chat{}, g.onHistory),
+		gw.On(&gateway.Connected{}, g.onHistoryConnected),
+	}
 
-	for wid := range g.Gateways {
-		g.Relay[id][wid] = NewRelay(g.Gateways[wid], g.Gateways[id], g.Config.GetRelay(id, wid))
+	g.ConfigMut.Lock()
+	for wid := range gws {
+		rel[id][wid] = NewRelay(gws[wid], gw, g.relayConfig(id, wid))
 		if id == wid {
 			continue
 		}
-		g.Relay[wid][id] = NewRelay(g.Gateways[id], g.Gateways[wid], g.Config.GetRelay(wid, id))
+		rel[wid][id] = NewRelay(gw, gws[wid], g.relayConfig(wid, id))
 	}
+	g.ConfigMut.Unlock()
 
-	gw.On(nil, func(ev *network.Event) {
+	h = append(h, gw.On(nil, func(ev *network.Event) {
 		// Add sender to all events
 		ev.Opt = append([]network.EventArg{gw}, ev.Opt...)
 
@@ -99,7 +245,16 @@ func (g *Goop) AddGateway(id string, gw gateway.Gateway) error {
 		if g.Fire(ev.Arg, ev.Opt...) {
 			ev.PreventNext()
 		}
-	})
+	}))
+
+	if g.handlers == nil {
+		g.handlers = make(map[string][]network.EventID)
+	}
+	g.handlers[id] = h
+
+	g.gateways.Store(gws)
+	g.relay.Store(rel)
+	g.gwmut.Unlock()
 
 	g.Fire(&NewGateway{
 		Gateway: gw,
@@ -108,14 +263,123 @@ func (g *Goop) AddGateway(id string, gw gateway.Gateway) error {
 	return nil
 }
 
+// relayConfig returns the relay configuration for from => to, must be called with ConfigMut held
+func (g *Goop) relayConfig(to, from string) *RelayConfig {
+	if g.Config == nil {
+		return &RelayConfig{}
+	}
+	return g.Config.GetRelay(to, from)
+}
+
+// RemoveGateway (and its sub gateways) from goop, stops running if needed
+func (g *Goop) RemoveGateway(id string) error {
+	g.gwmut.Lock()
+	var prevGws = g.Gateways()
+	if prevGws[id] == nil {
+		g.gwmut.Unlock()
+		return ErrUnknownGateway
+	}
+
+	var del = map[string]gateway.Gateway{}
+	for k, gw := range prevGws {
+		if k == id || strings.HasPrefix(k, id+gateway.Delimiter) {
+			del[k] = gw
+		}
+	}
+
+	// Copy on write, see AddGateway
+	var gws = make(map[string]gateway.Gateway, len(prevGws))
+	for k, v := range prevGws {
+		if del[k] == nil {
+			gws[k] = v
+		}
+	}
+
+	var prevRel = g.Relay()
+	var rel = make(map[string]map[string]*Relay, len(prevRel))
+	for k, v := range prevRel {
+		if del[k] != nil {
+			for _, r := range v {
+				r.Close()
+			}
+			continue
+		}
+
+		rel[k] = make(map[string]*Relay, len(v))
+		for kk, vv := range v {
+			if del[kk] != nil {
+				vv.Close()
+				continue
+			}
+			rel[k][kk] = vv
+		}
+	}
+
+	for k, gw := range del {
+		for _, h := range g.handlers[k] {
+			gw.Off(h)
+		}
+		delete(g.handlers, k)
+	}
+
+	g.gateways.Store(gws)
+	g.relay.Store(rel)
+	g.gwmut.Unlock()
+
+	for k, gw := range del {
+		// Only fails if not running
+		g.disconnect(k)
+
+		g.Fire(&RemovedGateway{
+			Gateway: gw,
+		})
+	}
+
+	return nil
+}
+
+// StartGateway creates gateway id with Factory, adds it to goop, and runs it
+// Returns the IDs of all added gateways
+func (g *Goop) StartGateway(id string) ([]string, error) {
+	if g.Factory == nil {
+		return nil, ErrNoFactory
+	}
+
+	ids, err := g.Factory(g, id)
+	if err != nil {
+		return ids, err
+	}
+
+	g.SyncCommands()
+	for _, k := range ids {
+		if err := g.Connect(k); err != nil {
+			return ids, err
+		}
+	}
+
+	return ids, nil
+}
+
 // AddCommand to goop
 func (g *Goop) AddCommand(name string, c Command) error {
 	name = strings.ToLower(name)
-	if g.Commands[name] != nil {
+
+	g.cmdmut.Lock()
+	var prev = g.Commands()
+	if prev[name] != nil {
+		g.cmdmut.Unlock()
 		return ErrDuplicateCommand
 	}
 
-	g.Commands[name] = c
+	// Copy on write, commands may be added while running
+	var cmds = make(map[string]Command, len(prev)+1)
+	for k, v := range prev {
+		cmds[k] = v
+	}
+	cmds[name] = c
+
+	g.commands.Store(cmds)
+	g.cmdmut.Unlock()
 
 	g.Fire(&NewCommand{
 		Name:    name,
@@ -125,109 +389,248 @@ func (g *Goop) AddCommand(name string, c Command) error {
 	return nil
 }
 
-// Run connects to each gateway and returns when all connections have ended
-func (g *Goop) Run(ctx context.Context) {
-	g.Fire(Start{})
+// RemoveCommand from goop
+func (g *Goop) RemoveCommand(name string) error {
+	name = strings.ToLower(name)
 
-	var wg sync.WaitGroup
-	for i := range g.Gateways {
-		wg.Add(1)
+	g.cmdmut.Lock()
+	var prev = g.Commands()
+	var c = prev[name]
+	if c == nil {
+		g.cmdmut.Unlock()
+		return ErrUnknownCommand
+	}
 
-		var k = i
-		var r = g.Gateways[k]
-		go func() {
-			if err := r.Run(ctx); err != nil && err != context.Canceled {
-				g.Fire(&network.AsyncError{Src: fmt.Sprintf("Run[gw:%s]", k), Err: err})
-			}
-			wg.Done()
-		}()
+	// Copy on write, see AddCommand
+	var cmds = make(map[string]Command, len(prev))
+	for k, v := range prev {
+		if k != name {
+			cmds[k] = v
+		}
 	}
 
-	wg.Wait()
-	g.Fire(Stop{})
+	g.commands.Store(cmds)
+	g.cmdmut.Unlock()
+
+	g.Fire(&RemovedCommand{
+		Name:    name,
+		Command: c,
+	})
+
+	return nil
 }
 
-func checkTriggerChat(ev *network.Event) {
-	var msg = ev.Arg.(*gateway.Chat)
-	if msg.User.Access < gateway.AccessVoice || !strings.EqualFold(msg.Content, "?trigger") {
-		return
+// SyncCommands passes the list of enabled commands to each CommandSetter gateway
+func (g *Goop) SyncCommands() {
+	var all = gateway.Trigger{User: gateway.User{Access: gateway.AccessMax}}
+	var cmds = make(map[string]string)
+	for name, c := range g.Commands() {
+		if c.CanExecute(&all) {
+			cmds[name] = CommandDescription(c)
+		}
 	}
+
+	for _, gw := range g.Gateways() {
+		if s, ok := gw.(CommandSetter); ok {
+			s.SetCommands(cmds)
+		}
+	}
+}
+
+// findTriggerOverride checks if s starts with a trigger override of a command, return Trigger{} if true
+func (g *Goop) findTriggerOverride(s string) *gateway.Trigger {
+	var lower = strings.ToLower(s)
+	for name, c := range g.Commands() {
+		o, ok := c.(CommandTriggerOverride)
+		if !ok {
+			continue
+		}
+		for _, p := range o.TriggerOverride() {
+			if !strings.HasPrefix(lower, strings.ToLower(p)) {
+				continue
+			}
+			if t := gateway.ExtractTrigger(s[len(p):]); t != nil && strings.EqualFold(t.Cmd, name) {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Goop) checkTriggerOverride(ev *network.Event, u *gateway.User, s string, private bool) {
 	gw, ok := ev.Opt[0].(gateway.Gateway)
-	if !ok || gw.Trigger() == "?" {
+	if !ok {
 		return
 	}
+	if f, ok := gw.(gateway.TriggerFinder); ok && (u.Access < f.TriggerAccess() || f.FindTrigger(s) != nil) {
+		// Already handled by gateway
+		return
+	}
+
+	if t := g.findTriggerOverride(s); t != nil {
+		t.User = *u
+		t.Resp = gw.Responder(gw, u.ID, private)
+		gw.Fire(t, ev.Arg)
+	}
+}
 
-	gw.Fire(&gateway.Trigger{
-		User: msg.User,
-		Cmd:  "trigger",
-		Resp: gw.Responder(gw, msg.User.ID, false),
-	}, ev.Arg)
+func (g *Goop) checkTriggerChat(ev *network.Event) {
+	var msg = ev.Arg.(*gateway.Chat)
+	g.checkTriggerOverride(ev, &msg.User, msg.Content, false)
 }
 
-func checkTriggerPrivateChat(ev *network.Event) {
+func (g *Goop) checkTriggerPrivateChat(ev *network.Event) {
 	var msg = ev.Arg.(*gateway.PrivateChat)
-	if msg.User.Access < gateway.AccessVoice || !strings.EqualFold(msg.Content, "?trigger") {
+	g.checkTriggerOverride(ev, &msg.User, msg.Content, true)
+}
+
+// execResult is passed along with Trigger events fired by Exec, execTrigger stores the result in it
+type execResult struct {
+	ok  bool
+	err error
+}
+
+func (g *Goop) execTrigger(ev *network.Event) {
+	var t = ev.Arg.(*gateway.Trigger)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok {
 		return
 	}
-	gw, ok := ev.Opt[0].(gateway.Gateway)
-	if !ok || gw.Trigger() == "?" {
+
+	if l := g.ActiveLockdown(gw); l != nil && t.User.Access < l.Access {
+		// Triggers are fired separately from the chat message that was dropped by lockdownChat
 		return
 	}
 
-	gw.Fire(&gateway.Trigger{
-		User: msg.User,
-		Cmd:  "trigger",
-		Resp: gw.Responder(gw, msg.User.ID, true),
-	}, ev.Arg)
+	for _, o := range ev.Opt[1:] {
+		if r, ok := o.(*execResult); ok {
+			// Fired by Exec, which waits for the command to finish
+			r.ok, r.err = g.exec(t, gw)
+			return
+		}
+	}
+
+	var chain = stages(t, gw)
+
+	go func() {
+		var err error
+		if len(chain) > 1 {
+			err = g.execChain(chain, gw)
+		} else {
+			_, err = g.exec(chain[0].Trigger, gw)
+		}
+		if err != nil {
+			g.Fire(&network.AsyncError{Src: "execTrigger", Err: err})
+		}
+	}()
+}
+
+// Exec fires t as Trigger event on gw and waits for the command to finish
+// Returns false if the command was not found, could not be executed by t.User, or if the event was prevented
+func (g *Goop) Exec(t *gateway.Trigger, gw gateway.Gateway) (bool, error) {
+	if t.Depth >= MaxExecDepth {
+		return false, ErrExecDepth
+	}
+
+	var trig = *t
+	trig.Depth++
+
+	var res execResult
+	gw.Fire(&trig, &res)
+	return res.ok, res.err
 }
 
-func (g *Goop) execTrigger(ev *network.Event) {
-	var t = *ev.Arg.(*gateway.Trigger)
+// exec runs command t.Cmd on gw and waits for it to finish
+// Commands prefixed with a gateway pattern (i.e. capi:kick) are executed on each matching gateway
+// Returns false if the command was not found or could not be executed by t.User
+func (g *Goop) exec(t *gateway.Trigger, gw gateway.Gateway) (bool, error) {
+	var trig = *t
 
-	t.Cmd = strings.ToLower(t.Cmd)
-	if c, ok := g.Commands[strings.ToLower(t.Cmd)]; ok {
-		gw, ok := ev.Opt[0].(gateway.Gateway)
-		if !ok || !c.CanExecute(&t) {
-			return
+	var cmds = g.Commands()
+
+	trig.Cmd = strings.ToLower(trig.Cmd)
+	if c, ok := cmds[trig.Cmd]; ok {
+		if !c.CanExecute(&trig) {
+			return false, nil
 		}
-		go func() {
-			if err := c.Execute(&t, gw, g); err != nil {
-				g.Fire(&network.AsyncError{Src: "execTrigger", Err: err})
-			}
-		}()
-		return
+		ok, cancel := g.checkCooldown(trig.Cmd, c, gw, &trig)
+		if !ok {
+			return false, nil
+		}
+
+		var err = c.Execute(&trig, gw, g)
+		if trig.UsageError() != nil {
+			// Invalid arguments, do not count towards cooldown
+			cancel()
+		}
+		return true, err
 	}
 
-	var s = strings.Split(t.Cmd, gateway.Delimiter)
+	var s = strings.Split(trig.Cmd, gateway.Delimiter)
 	if len(s) < 2 {
-		return
+		return false, nil
 	}
 
-	t.Cmd = s[len(s)-1]
-	c, ok := g.Commands[t.Cmd]
-	if !ok || !c.CanExecute(&t) {
-		return
+	trig.Cmd = s[len(s)-1]
+	c, ok := cmds[trig.Cmd]
+	if !ok || !c.CanExecute(&trig) {
+		return false, nil
+	}
+	ok, cancel := g.checkCooldown(trig.Cmd, c, gw, &trig)
+	if !ok {
+		return false, nil
 	}
 
+	var wg sync.WaitGroup
+	var mut sync.Mutex
+	var res error
+	var usage = true
+
 	var p = strings.ToLower(fmt.Sprintf("*%s%s%s*", gateway.Delimiter, strings.Join(s[:len(s)-1], gateway.Delimiter), gateway.Delimiter))
-	for k := range g.Gateways {
-		var gw = g.Gateways[k]
+	var gws = g.Gateways()
+	for k := range gws {
+		var target = gws[k]
 		if ok, err := filepath.Match(p, gateway.Delimiter+strings.ToLower(k)+gateway.Delimiter); err != nil || !ok {
 			continue
 		}
-		if gw.Channel() == nil {
+		if target.Channel() == nil {
 			continue
 		}
 
-		var trig = t
-		trig.Resp = func(s string) error { return t.Resp(fmt.Sprintf("[%s] %s", gw.Discriminator(), s)) }
+		var tt = trig
+		tt.Resp = func(s string) error { return trig.Resp(fmt.Sprintf("[%s] %s", target.Discriminator(), s)) }
 
+		var id = k
+		wg.Add(1)
 		go func() {
-			if err := c.Execute(&trig, gw, g); err != nil {
-				g.Fire(&network.AsyncError{Src: "execTrigger", Err: err})
+			if err := c.Execute(&tt, target, g); err != nil {
+				mut.Lock()
+				var first = res == nil
+				if first {
+					res = err
+				}
+				mut.Unlock()
+
+				// Only the first error is returned, report the others separately
+				if !first {
+					g.Fire(&network.AsyncError{Src: fmt.Sprintf("exec[gw:%s]", id), Err: err})
+				}
 			}
+
+			mut.Lock()
+			usage = usage && tt.UsageError() != nil
+			mut.Unlock()
+
+			wg.Done()
 		}()
 	}
+
+	wg.Wait()
+	if usage {
+		// Invalid arguments on every gateway, do not count towards cooldown
+		cancel()
+	}
+	return true, res
 }
 
 func (g *Goop) autoKick(gw gateway.Gateway, u *gateway.User) bool {
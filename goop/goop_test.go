@@ -0,0 +1,141 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+type testConfig struct {
+	Relay     map[string]map[string]*goop.RelayConfig
+	Cooldown  goop.CooldownConfig
+	Seen      goop.SeenConfig
+	Mail      goop.MailConfig
+	Schedule  goop.ScheduleConfig
+	Trivia    goop.TriviaConfig
+	Points    goop.PointsConfig
+	Highlight goop.HighlightConfig
+	History   goop.HistoryConfig
+	Away      goop.AwayConfig
+	Reminder  goop.ReminderConfig
+	Identity  goop.IdentityConfig
+}
+
+func (c *testConfig) GetRelay(to, from string) *goop.RelayConfig {
+	if r := c.Relay[to][from]; r != nil {
+		return r
+	}
+	return &goop.RelayConfig{}
+}
+
+func (c *testConfig) GetCooldown() *goop.CooldownConfig      { return &c.Cooldown }
+func (c *testConfig) GetSeen() *goop.SeenConfig              { return &c.Seen }
+func (c *testConfig) GetMail() *goop.MailConfig              { return &c.Mail }
+func (c *testConfig) GetSchedule() *goop.ScheduleConfig      { return &c.Schedule }
+func (c *testConfig) GetTrivia() *goop.TriviaConfig          { return &c.Trivia }
+func (c *testConfig) GetPoints() *goop.PointsConfig          { return &c.Points }
+func (c *testConfig) GetHighlight() *goop.HighlightConfig    { return &c.Highlight }
+func (c *testConfig) GetHistory() *goop.HistoryConfig        { return &c.History }
+func (c *testConfig) GetAway() *goop.AwayConfig              { return &c.Away }
+func (c *testConfig) GetReminder() *goop.ReminderConfig      { return &c.Reminder }
+func (c *testConfig) GetIdentity() *goop.IdentityConfig      { return &c.Identity }
+func (c *testConfig) Map() map[string]interface{}            { return nil }
+func (c *testConfig) FlatMap() map[string]interface{}        { return nil }
+func (c *testConfig) Get(key string) (interface{}, error)    { return nil, nil }
+func (c *testConfig) Set(key string, val interface{}) error  { return nil }
+func (c *testConfig) Unset(key string) (err error)           { return nil }
+func (c *testConfig) GetString(key string) (string, error)   { return "", nil }
+func (c *testConfig) SetString(key string, val string) error { return nil }
+
+type testGateway struct {
+	gateway.Common
+	gateway.Config
+	network.EventEmitter
+
+	mut     sync.Mutex
+	users   map[string]gateway.AccessLevel
+	said    []string
+	private map[string][]string
+
+	// Returned by SayPrivate if set
+	privateErr error
+}
+
+func newTestGateway(users ...gateway.User) *testGateway {
+	var res = &testGateway{
+		users:   make(map[string]gateway.AccessLevel),
+		private: make(map[string][]string),
+	}
+	for _, u := range users {
+		res.users[u.ID] = u.Access
+	}
+	return res
+}
+
+func (o *testGateway) Channel() *gateway.Channel              { return &gateway.Channel{ID: "chan", Name: "chan"} }
+func (o *testGateway) ChannelUsers() []gateway.User           { return nil }
+func (o *testGateway) User(uid string) (*gateway.User, error) { return nil, gateway.ErrNoUser }
+func (o *testGateway) Kick(uid string) error                  { return gateway.ErrNotImplemented }
+func (o *testGateway) Ban(uid string) error                   { return gateway.ErrNotImplemented }
+func (o *testGateway) Unban(uid string) error                 { return gateway.ErrNotImplemented }
+func (o *testGateway) Run(ctx context.Context) error          { <-ctx.Done(); return ctx.Err() }
+
+func (o *testGateway) Ping(uid string) (time.Duration, error) {
+	return 0, gateway.ErrNotImplemented
+}
+
+func (o *testGateway) Relay(ev *network.Event, from gateway.Gateway) error {
+	return gateway.ErrUnknownEvent
+}
+
+func (o *testGateway) Users() map[string]gateway.AccessLevel {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	var res = make(map[string]gateway.AccessLevel, len(o.users))
+	for k, v := range o.users {
+		res[k] = v
+	}
+	return res
+}
+
+func (o *testGateway) SetUserAccess(uid string, a gateway.AccessLevel) (*gateway.AccessLevel, error) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	var prev = o.users[uid]
+	o.users[uid] = a
+	return &prev, nil
+}
+
+func (o *testGateway) Say(s string) error {
+	o.mut.Lock()
+	o.said = append(o.said, s)
+	o.mut.Unlock()
+	return nil
+}
+
+func (o *testGateway) SayPrivate(uid string, s string) error {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	if o.privateErr != nil {
+		return o.privateErr
+	}
+	o.private[uid] = append(o.private[uid], s)
+	return nil
+}
+
+func (o *testGateway) whispers(uid string) []string {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+	return append([]string{}, o.private[uid]...)
+}
@@ -0,0 +1,226 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrHighlightLimit = errors.New("goop: Too many highlight keywords")
+)
+
+// HighlightConfig stores keyword subscriptions per gateway and user ID
+type HighlightConfig struct {
+	Disabled bool
+
+	// Minimum time between notifications for the same subscriber
+	Interval time.Duration
+
+	// Subscribers that talked in the source channel within Idle are not notified
+	Idle time.Duration
+
+	Users map[string]map[string]*HighlightUser
+}
+
+// HighlightUser stores the keywords a user is subscribed to
+type HighlightUser struct {
+	Name     string
+	Keywords []string
+}
+
+type highlights struct {
+	mut      sync.Mutex
+	active   map[string]time.Time
+	notified map[string]time.Time
+}
+
+// AddHighlight subscribes user u on gw to keyword, fails if they already have limit keywords
+// Returns false if they were already subscribed
+func (g *Goop) AddHighlight(gw gateway.Gateway, u *gateway.User, keyword string, limit int) (bool, error) {
+	if g.Config == nil {
+		return false, ErrHighlightLimit
+	}
+
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetHighlight()
+	if conf.Users == nil {
+		conf.Users = make(map[string]map[string]*HighlightUser)
+	}
+	if conf.Users[gw.ID()] == nil {
+		conf.Users[gw.ID()] = make(map[string]*HighlightUser)
+	}
+
+	var h = conf.Users[gw.ID()][u.ID]
+	if h == nil {
+		h = &HighlightUser{}
+		conf.Users[gw.ID()][u.ID] = h
+	}
+	h.Name = u.Name
+
+	for _, k := range h.Keywords {
+		if k == keyword {
+			return false, nil
+		}
+	}
+	if limit > 0 && len(h.Keywords) >= limit {
+		return false, ErrHighlightLimit
+	}
+
+	h.Keywords = append(h.Keywords, keyword)
+	sort.Strings(h.Keywords)
+	return true, nil
+}
+
+// RemoveHighlight unsubscribes uid on gw from keyword, returns false if there was no such subscription
+func (g *Goop) RemoveHighlight(gw gateway.Gateway, uid string, keyword string) bool {
+	if g.Config == nil {
+		return false
+	}
+
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var users = g.Config.GetHighlight().Users[gw.ID()]
+	var h = users[uid]
+	if h == nil {
+		return false
+	}
+
+	for i, k := range h.Keywords {
+		if k != keyword {
+			continue
+		}
+		h.Keywords = append(h.Keywords[:i], h.Keywords[i+1:]...)
+		if len(h.Keywords) == 0 {
+			delete(users, uid)
+		}
+		return true
+	}
+	return false
+}
+
+// Highlights returns the keywords uid on gw is subscribed to
+func (g *Goop) Highlights(gw gateway.Gateway, uid string) []string {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var h = g.Config.GetHighlight().Users[gw.ID()][uid]
+	if h == nil {
+		return nil
+	}
+	return append([]string{}, h.Keywords...)
+}
+
+// HighlightMatch returns the first keyword that appears as a whole word (or phrase) in msg
+func HighlightMatch(keywords []string, msg string) string {
+	msg = strings.ToLower(msg)
+	for _, k := range keywords {
+		if k == "" || !strings.Contains(msg, k) {
+			continue
+		}
+		if m, _ := regexp.MatchString(`(^|\W)`+regexp.QuoteMeta(k)+`($|\W)`, msg); m {
+			return k
+		}
+	}
+	return ""
+}
+
+type highlightNotification struct {
+	gw  gateway.Gateway
+	uid string
+}
+
+func (g *Goop) onHighlight(ev *network.Event) {
+	var msg = ev.Arg.(*gateway.Chat)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok || g.Config == nil {
+		return
+	}
+
+	var now = time.Now()
+	var src = gw.ID() + gateway.Delimiter + msg.User.ID
+
+	g.ConfigMut.Lock()
+	var conf = g.Config.GetHighlight()
+	var interval = conf.Interval
+	var idle = conf.Idle
+
+	var notify = []highlightNotification{}
+	if !conf.Disabled {
+		for gid, users := range conf.Users {
+			var sgw = g.Gateways()[gid]
+			if sgw == nil {
+				continue
+			}
+
+			// Only notify subscribers on gateways that chat is relayed to
+			if !g.RelaysChat(gid, gw.ID(), msg.User.Access) {
+				continue
+			}
+			for uid, h := range users {
+				if h == nil || (gid == gw.ID() && uid == msg.User.ID) || HighlightMatch(h.Keywords, msg.Content) == "" {
+					continue
+				}
+				notify = append(notify, highlightNotification{gw: sgw, uid: uid})
+			}
+		}
+	}
+	g.ConfigMut.Unlock()
+
+	var where = gw.Discriminator()
+	if c := gw.Channel(); c != nil {
+		where = c.Name
+	}
+
+	g.highlights.mut.Lock()
+	if g.highlights.active == nil {
+		g.highlights.active = make(map[string]time.Time)
+		g.highlights.notified = make(map[string]time.Time)
+	}
+	g.highlights.active[src] = now
+
+	var send = notify[:0]
+	for _, n := range notify {
+		var key = n.gw.ID() + gateway.Delimiter + n.uid
+		if n.gw == gw && now.Sub(g.highlights.active[key]) < idle {
+			// Subscriber is active in this channel, they will see it anyway
+			continue
+		}
+		if now.Sub(g.highlights.notified[key]) < interval {
+			continue
+		}
+		g.highlights.notified[key] = now
+		send = append(send, n)
+	}
+	g.highlights.mut.Unlock()
+
+	for _, n := range send {
+		var s = fmt.Sprintf("[%s] <%s> %s", where, msg.User.Name, msg.Content)
+		if err := n.gw.SayPrivate(n.uid, s); err != nil && err != gateway.ErrNotImplemented {
+			g.Fire(&network.AsyncError{Src: "onHighlight", Err: err})
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestHighlightMatch(t *testing.T) {
+	var keywords = []string{"niels", "clan abc", "c++"}
+	var cases = map[string]string{
+		"hi Niels!":            "niels",
+		"nielsAD":              "",
+		"anyone from clan ABC": "clan abc",
+		"clan abcd":            "",
+		"I like c++ too":       "c++",
+		"nothing":              "",
+	}
+	for msg, k := range cases {
+		if m := goop.HighlightMatch(keywords, msg); m != k {
+			t.Fatalf("%s: expected %q, got %q", msg, k, m)
+		}
+	}
+}
+
+func TestHighlightRelay(t *testing.T) {
+	var alice = gateway.User{ID: "alice", Name: "Alice", Access: gateway.AccessVoice}
+	var bob = gateway.User{ID: "bob", Name: "Bob"}
+	var carol = gateway.User{ID: "carol", Name: "Carol"}
+	var dave = gateway.User{ID: "dave", Name: "Dave"}
+
+	var g = goop.New(&testConfig{
+		Relay: map[string]map[string]*goop.RelayConfig{
+			"relayed": {"src": {Chat: true, ChatAccess: gateway.AccessVoice}},
+		},
+	})
+	var src = newTestGateway()
+	var relayed = newTestGateway()
+	var private = newTestGateway()
+	for id, gw := range map[string]*testGateway{"src": src, "relayed": relayed, "private": private} {
+		if err := g.AddGateway(id, gw); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g.AddHighlight(src, &bob, "hello", 0)
+	g.AddHighlight(relayed, &carol, "hello", 0)
+	g.AddHighlight(private, &dave, "hello", 0)
+
+	src.Fire(&gateway.Chat{User: alice, Content: "hello world"})
+	if len(src.whispers(bob.ID)) != 1 {
+		t.Fatal("Expected subscriber on source gateway to be notified")
+	}
+	if len(relayed.whispers(carol.ID)) != 1 {
+		t.Fatal("Expected subscriber on relayed gateway to be notified")
+	}
+	if len(private.whispers(dave.ID)) != 0 {
+		t.Fatal("Expected subscriber on gateway without relay to be ignored")
+	}
+
+	// Chat below ChatAccess is not relayed either
+	var eve = gateway.User{ID: "eve", Name: "Eve", Access: gateway.AccessDefault}
+	src.Fire(&gateway.Chat{User: eve, Content: "hello again"})
+	if len(relayed.whispers(carol.ID)) != 1 {
+		t.Fatal("Expected chat below ChatAccess to be ignored")
+	}
+}
@@ -0,0 +1,141 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// HistoryConfig stores the recent chat messages per gateway
+type HistoryConfig struct {
+	// Number of messages kept per gateway (0 to disable)
+	Size int
+
+	// Store messages in configuration, so they survive a restart
+	Persist  bool
+	Messages map[string][]*HistoryMessage
+}
+
+// HistoryMessage is a chat message in the history buffer
+type HistoryMessage struct {
+	Time    time.Time
+	User    string
+	Access  gateway.AccessLevel
+	Message string
+}
+
+func (m *HistoryMessage) String() string {
+	return fmt.Sprintf("[%s] <%s> %s", m.Time.Format("15:04:05"), m.User, m.Message)
+}
+
+type histories struct {
+	messages map[string][]*HistoryMessage
+}
+
+// must be called with ConfigMut held
+func (g *Goop) historyMessages() map[string][]*HistoryMessage {
+	var conf = g.Config.GetHistory()
+	if conf.Persist {
+		if conf.Messages == nil {
+			conf.Messages = make(map[string][]*HistoryMessage)
+		}
+		return conf.Messages
+	}
+
+	if g.histories.messages == nil {
+		g.histories.messages = make(map[string][]*HistoryMessage)
+	}
+	return g.histories.messages
+}
+
+// History returns the last n chat messages on gateway gid, oldest first
+func (g *Goop) History(gid string, n int) []HistoryMessage {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var h = g.historyMessages()[gid]
+	if n > 0 && len(h) > n {
+		h = h[len(h)-n:]
+	}
+
+	var res = make([]HistoryMessage, 0, len(h))
+	for _, m := range h {
+		if m != nil {
+			res = append(res, *m)
+		}
+	}
+	return res
+}
+
+func (g *Goop) onHistory(ev *network.Event) {
+	var msg = ev.Arg.(*gateway.Chat)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok || g.Config == nil {
+		return
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var size = g.Config.GetHistory().Size
+	if size <= 0 {
+		return
+	}
+
+	var all = g.historyMessages()
+	var h = append(all[gw.ID()], &HistoryMessage{
+		Time:    time.Now(),
+		User:    msg.User.Name,
+		Access:  msg.User.Access,
+		Message: msg.Content,
+	})
+	if len(h) > size {
+		h = append([]*HistoryMessage{}, h[len(h)-size:]...)
+	}
+	all[gw.ID()] = h
+}
+
+// onHistoryConnected relays recent chat messages of gw to gateways that have History enabled in their relay
+func (g *Goop) onHistoryConnected(ev *network.Event) {
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok {
+		return
+	}
+
+	for _, rel := range g.Relay() {
+		var r = rel[gw.ID()]
+		if r == nil || r.To == gw || r.History <= 0 {
+			continue
+		}
+
+		// Skip messages that the relay would not have relayed either
+		var l = []string{}
+		for _, m := range g.History(gw.ID(), 0) {
+			if m.Access >= r.ChatAccess {
+				l = append(l, m.String())
+			}
+		}
+		if len(l) == 0 {
+			continue
+		}
+		if len(l) > r.History {
+			l = l[len(l)-r.History:]
+		}
+
+		r.relay(&network.Event{Arg: &gateway.SystemMessage{
+			Type:    "HISTORY",
+			Content: "Recent messages:\n" + strings.Join(l, "\n"),
+		}})
+	}
+}
@@ -0,0 +1,45 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"github.com/nielsAD/goop/gateway"
+)
+
+// IdentityConfig links user accounts on different gateways to a single identity
+type IdentityConfig struct {
+	// Identity name per gateway and user ID
+	Users map[string]map[string]string
+}
+
+// must be called with ConfigMut held
+func (g *Goop) identity(gid string, uid string) string {
+	if g.Config == nil {
+		return ""
+	}
+	return g.Config.GetIdentity().Users[gid][uid]
+}
+
+// must be called with ConfigMut held
+func (g *Goop) identityKey(gid string, uid string) string {
+	if id := g.identity(gid, uid); id != "" {
+		return gateway.Delimiter + id
+	}
+	return gid + gateway.Delimiter + uid
+}
+
+// Identity returns the identity that uid on gateway gid is linked to, empty if not linked
+func (g *Goop) Identity(gid string, uid string) string {
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+	return g.identity(gid, uid)
+}
+
+// IdentityKey returns a key that is shared by all accounts linked to the same identity as uid on gateway gid
+func (g *Goop) IdentityKey(gid string, uid string) string {
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+	return g.identityKey(gid, uid)
+}
@@ -0,0 +1,110 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Lockdown state of a gateway
+type Lockdown struct {
+	Access gateway.AccessLevel
+	Ends   time.Time
+
+	timer *time.Timer
+}
+
+type lockdowns struct {
+	mut sync.Mutex
+	gws map[string]*Lockdown
+}
+
+// StartLockdown kicks users below access that join gw, and ignores their messages, until d has passed
+func (g *Goop) StartLockdown(gw gateway.Gateway, access gateway.AccessLevel, d time.Duration) {
+	var l = &Lockdown{
+		Access: access,
+		Ends:   time.Now().Add(d),
+	}
+
+	g.lockdowns.mut.Lock()
+	if g.lockdowns.gws == nil {
+		g.lockdowns.gws = make(map[string]*Lockdown)
+	}
+	if o := g.lockdowns.gws[gw.ID()]; o != nil {
+		o.timer.Stop()
+	}
+	l.timer = time.AfterFunc(d, func() { g.endLockdown(gw, l) })
+	g.lockdowns.gws[gw.ID()] = l
+	g.lockdowns.mut.Unlock()
+
+	if err := gw.Say(fmt.Sprintf("Channel locked down for users below <%s> for %s", access, d)); err != nil {
+		g.Fire(&network.AsyncError{Src: "StartLockdown", Err: err})
+	}
+}
+
+// StopLockdown lifts lockdown on gw, returns false if there was none
+func (g *Goop) StopLockdown(gw gateway.Gateway) bool {
+	g.lockdowns.mut.Lock()
+	var l = g.lockdowns.gws[gw.ID()]
+	g.lockdowns.mut.Unlock()
+
+	if l == nil {
+		return false
+	}
+
+	l.timer.Stop()
+	return g.endLockdown(gw, l)
+}
+
+func (g *Goop) endLockdown(gw gateway.Gateway, l *Lockdown) bool {
+	g.lockdowns.mut.Lock()
+	if g.lockdowns.gws[gw.ID()] != l {
+		g.lockdowns.mut.Unlock()
+		return false
+	}
+	delete(g.lockdowns.gws, gw.ID())
+	g.lockdowns.mut.Unlock()
+
+	if err := gw.Say("Lockdown lifted"); err != nil {
+		g.Fire(&network.AsyncError{Src: "endLockdown", Err: err})
+	}
+	return true
+}
+
+// ActiveLockdown on gw, nil if there is none
+func (g *Goop) ActiveLockdown(gw gateway.Gateway) *Lockdown {
+	g.lockdowns.mut.Lock()
+	defer g.lockdowns.mut.Unlock()
+	return g.lockdowns.gws[gw.ID()]
+}
+
+func (g *Goop) lockdownJoin(ev *network.Event) {
+	var user = ev.Arg.(*gateway.Join)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok {
+		return
+	}
+
+	if l := g.ActiveLockdown(gw); l != nil && user.Access < l.Access && g.autoKick(gw, &user.User) {
+		ev.PreventNext()
+	}
+}
+
+func (g *Goop) lockdownChat(ev *network.Event) {
+	var msg = ev.Arg.(*gateway.Chat)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok {
+		return
+	}
+
+	if l := g.ActiveLockdown(gw); l != nil && msg.User.Access < l.Access {
+		ev.PreventNext()
+	}
+}
@@ -0,0 +1,48 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+type testCommand struct{ n int }
+
+func (c *testCommand) CanExecute(t *gateway.Trigger) bool { return true }
+func (c *testCommand) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	c.n++
+	return nil
+}
+
+func TestLockdownTrigger(t *testing.T) {
+	var g = goop.New(&testConfig{})
+	var gw = newTestGateway()
+	if err := g.AddGateway("test", gw); err != nil {
+		t.Fatal(err)
+	}
+
+	var c testCommand
+	g.AddCommand("cmd", &c)
+
+	var voice = &gateway.Trigger{User: gateway.User{ID: "voice", Access: gateway.AccessVoice}, Cmd: "cmd"}
+	var op = &gateway.Trigger{User: gateway.User{ID: "op", Access: gateway.AccessOperator}, Cmd: "cmd"}
+
+	g.StartLockdown(gw, gateway.AccessWhitelist, time.Minute)
+	if ok, _ := g.Exec(voice, gw); ok || c.n != 0 {
+		t.Fatal("Expected command below lockdown access to be ignored")
+	}
+	if ok, _ := g.Exec(op, gw); !ok || c.n != 1 {
+		t.Fatal("Expected command above lockdown access to be executed")
+	}
+
+	g.StopLockdown(gw)
+	if ok, _ := g.Exec(voice, gw); !ok || c.n != 2 {
+		t.Fatal("Expected command to be executed after lockdown")
+	}
+}
@@ -0,0 +1,212 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrMailLimit = errors.New("goop: Too many pending messages")
+)
+
+// MaxMailRetries is the number of times delivery of a message is retried before it is dropped
+const MaxMailRetries = 5
+
+// MailConfig stores undelivered messages
+type MailConfig struct {
+	Messages []*Mail
+}
+
+// Mail is a message for a user that is delivered the next time they are seen
+type Mail struct {
+	Gateway     string
+	To          string
+	ToID        string
+	From        string
+	FromID      string
+	FromGateway string
+	FromAccess  gateway.AccessLevel
+	Message     string
+	Sent        time.Time
+	Retries     int
+}
+
+func (m *Mail) sentBy(gw string, uid string) bool {
+	return m.FromGateway == gw && m.FromID == uid
+}
+
+// mailFor returns true if m is for user u on gateway gw, must be called with ConfigMut held
+func (g *Goop) mailFor(m *Mail, gw string, u *gateway.User) bool {
+	// Recipient was resolved when the message was sent, so only deliver to that exact user
+	if m.Gateway == gw && m.ToID != "" {
+		return m.ToID == u.ID
+	}
+	if !strings.EqualFold(m.To, u.Name) {
+		return false
+	}
+
+	// Deliver by name on gateways that the sender's chat is relayed to
+	return m.Gateway == "" || g.RelaysChat(gw, m.Gateway, m.FromAccess)
+}
+
+// SendMail stores m until its recipient is seen, fails if sender already has limit pending messages
+func (g *Goop) SendMail(m *Mail, limit int) error {
+	if g.Config == nil {
+		return ErrMailLimit
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetMail()
+	if limit > 0 {
+		var n = 0
+		for _, p := range conf.Messages {
+			if p.sentBy(m.FromGateway, m.FromID) {
+				n++
+			}
+		}
+		if n >= limit {
+			return ErrMailLimit
+		}
+	}
+
+	if m.Sent.IsZero() {
+		m.Sent = time.Now()
+	}
+	m.To = strings.ToLower(m.To)
+
+	conf.Messages = append(conf.Messages, m)
+	return nil
+}
+
+// PendingMail returns the undelivered messages sent by uid on gw
+func (g *Goop) PendingMail(gw string, uid string) []Mail {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var res = make([]Mail, 0)
+	for _, m := range g.Config.GetMail().Messages {
+		if m.sentBy(gw, uid) {
+			res = append(res, *m)
+		}
+	}
+	return res
+}
+
+// ClearMail deletes the undelivered messages sent by uid on gw (to recipient, if not empty)
+// Returns the number of deleted messages
+func (g *Goop) ClearMail(gw string, uid string, to string) int {
+	var keep = func(m *Mail) bool {
+		return !m.sentBy(gw, uid) || (to != "" && !strings.EqualFold(m.To, to))
+	}
+	return len(g.takeMail(keep))
+}
+
+// takeMail removes and returns all messages for which keep returns false
+func (g *Goop) takeMail(keep func(m *Mail) bool) []*Mail {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetMail()
+	var res []*Mail
+	var rem = conf.Messages[:0]
+	for _, m := range conf.Messages {
+		if keep(m) {
+			rem = append(rem, m)
+		} else {
+			res = append(res, m)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+
+	for i := len(rem); i < len(conf.Messages); i++ {
+		conf.Messages[i] = nil
+	}
+	conf.Messages = rem
+	return res
+}
+
+// requeueMail puts m back in the queue, ordered by time sent
+func (g *Goop) requeueMail(m *Mail) {
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetMail()
+	var i = sort.Search(len(conf.Messages), func(i int) bool { return conf.Messages[i].Sent.After(m.Sent) })
+	conf.Messages = append(conf.Messages, nil)
+	copy(conf.Messages[i+1:], conf.Messages[i:])
+	conf.Messages[i] = m
+}
+
+func (g *Goop) deliverMail(gw gateway.Gateway, u *gateway.User) {
+	if u.ID == "" || u.Name == "" {
+		return
+	}
+
+	var mail = g.takeMail(func(m *Mail) bool {
+		return !g.mailFor(m, gw.ID(), u)
+	})
+	if len(mail) == 0 {
+		return
+	}
+
+	go func() {
+		for _, m := range mail {
+			var msg = fmt.Sprintf("Message from `%s` (%s ago): %s", m.From, time.Since(m.Sent).Round(time.Second), m.Message)
+			err := gw.SayPrivate(u.ID, msg)
+			if err == nil {
+				continue
+			}
+
+			// Never fall back to public chat, try again next time
+			m.Retries++
+			if m.Retries > MaxMailRetries {
+				if err != gateway.ErrNotImplemented {
+					g.Fire(&network.AsyncError{Src: "deliverMail", Err: err})
+				}
+				continue
+			}
+			g.requeueMail(m)
+		}
+	}()
+}
+
+func (g *Goop) onMail(ev *network.Event) {
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok {
+		return
+	}
+
+	switch v := ev.Arg.(type) {
+	case *gateway.Join:
+		g.deliverMail(gw, &v.User)
+	case *gateway.User:
+		g.deliverMail(gw, v)
+	case *gateway.Chat:
+		g.deliverMail(gw, &v.User)
+	case *gateway.PrivateChat:
+		g.deliverMail(gw, &v.User)
+	}
+}
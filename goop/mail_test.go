@@ -0,0 +1,91 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Timeout waiting for %s", what)
+}
+
+func TestMailDelivery(t *testing.T) {
+	var g = goop.New(&testConfig{
+		Relay: map[string]map[string]*goop.RelayConfig{
+			"relayed": {"src": {Chat: true}},
+		},
+	})
+	var src = newTestGateway()
+	var relayed = newTestGateway()
+	var private = newTestGateway()
+	for id, gw := range map[string]*testGateway{"src": src, "relayed": relayed, "private": private} {
+		if err := g.AddGateway(id, gw); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var m = &goop.Mail{Gateway: "src", To: "Bob", ToID: "bob", FromID: "alice", FromGateway: "src", Message: "hi"}
+	if err := g.SendMail(m, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	private.Fire(&gateway.Join{User: gateway.User{ID: "bob", Name: "Bob"}})
+	src.Fire(&gateway.Join{User: gateway.User{ID: "imposter", Name: "Bob"}})
+	if len(g.PendingMail("src", "alice")) != 1 {
+		t.Fatal("Expected mail to be kept for unrelayed gateway and other user ID")
+	}
+
+	relayed.Fire(&gateway.Join{User: gateway.User{ID: "123", Name: "bob"}})
+	waitFor(t, "delivery on relayed gateway", func() bool { return len(relayed.whispers("123")) == 1 })
+	if len(g.PendingMail("src", "alice")) != 0 {
+		t.Fatal("Expected mail to be delivered")
+	}
+}
+
+func TestMailRetry(t *testing.T) {
+	var g = goop.New(&testConfig{})
+	var gw = newTestGateway()
+	if err := g.AddGateway("test", gw); err != nil {
+		t.Fatal(err)
+	}
+	gw.privateErr = errors.New("DMs disabled")
+
+	var now = time.Now()
+	for i, to := range []string{"carol", "dave", "carol"} {
+		var m = &goop.Mail{Gateway: "test", To: to, FromID: "alice", FromGateway: "test", Sent: now.Add(time.Duration(i) * time.Second)}
+		if err := g.SendMail(m, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 1; i <= goop.MaxMailRetries+1; i++ {
+		gw.Fire(&gateway.Join{User: gateway.User{ID: "carol", Name: "Carol"}})
+		if i > goop.MaxMailRetries {
+			waitFor(t, "mail to be dropped", func() bool { return len(g.PendingMail("test", "alice")) == 1 })
+			break
+		}
+
+		waitFor(t, "mail to be queued again", func() bool {
+			var p = g.PendingMail("test", "alice")
+			return len(p) == 3 && p[0].Retries == i && p[2].Retries == i
+		})
+		var p = g.PendingMail("test", "alice")
+		if p[0].To != "carol" || p[1].To != "dave" || p[2].To != "carol" {
+			t.Fatalf("Expected queue order to be kept, got %v", p)
+		}
+	}
+}
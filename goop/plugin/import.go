@@ -147,9 +147,21 @@ func importMap(ls *lua.LState, m map[string]interface{}) *lua.LTable {
 
 // goop.Command wrapper for plugins
 type cmdCallback func(t *gateway.Trigger, gw gateway.Gateway) error
-type cmdWrapper struct{ cb cmdCallback }
+type cmdWrapper struct {
+	cb    cmdCallback
+	usage string
+	desc  string
 
+	Cooldown       time.Duration
+	GlobalCooldown time.Duration
+}
+
+func (c *cmdWrapper) Usage() string                      { return c.usage }
+func (c *cmdWrapper) Description() string                { return c.desc }
 func (c *cmdWrapper) CanExecute(t *gateway.Trigger) bool { return true }
+func (c *cmdWrapper) Cooldowns() (time.Duration, time.Duration) {
+	return c.Cooldown, c.GlobalCooldown
+}
 func (c *cmdWrapper) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
 	return c.cb(t, gw)
 }
@@ -183,19 +195,29 @@ var _global = map[string]interface{}{
 		ls.Push(ud)
 		return 1
 	},
-	"command": func(cb cmdCallback) goop.Command {
-		return &cmdWrapper{cb}
+	"command": func(cb cmdCallback, info ...string) goop.Command {
+		var c = cmdWrapper{cb: cb}
+		if len(info) > 0 {
+			c.usage = info[0]
+		}
+		if len(info) > 1 {
+			c.desc = info[1]
+		}
+		return &c
 	},
 	"command_alias": func(alias *cmd.Alias) *cmd.Alias {
 		return alias
 	},
+	"args": gateway.ParseArgSpec,
 }
 
 var _events = map[string]interface{}{
-	"Start":      goop.Start{},
-	"Stop":       goop.Stop{},
-	"NewGateway": &goop.NewGateway{},
-	"NewCommand": &goop.NewCommand{},
+	"Start":          goop.Start{},
+	"Stop":           goop.Stop{},
+	"NewGateway":     &goop.NewGateway{},
+	"RemovedGateway": &goop.RemovedGateway{},
+	"NewCommand":     &goop.NewCommand{},
+	"RemovedCommand": &goop.RemovedCommand{},
 
 	"RunStart": network.RunStart{},
 	"RunStop":  network.RunStop{},
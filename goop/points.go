@@ -0,0 +1,408 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrNotEnoughPoints = errors.New("goop: Not enough points")
+	ErrPointsDisabled  = errors.New("goop: Points disabled")
+)
+
+// PointsConfig stores the points ledger per gateway and user ID, and how points are earned
+type PointsConfig struct {
+	Disabled bool
+
+	// Points earned per chat message of at least MinLength characters, at most once per Interval
+	PerMessage int
+	Interval   time.Duration
+	MinLength  int
+
+	// Minimum time between karma given by the same user
+	KarmaCooldown time.Duration
+
+	Users map[string]map[string]*PointsUser
+}
+
+// PointsUser ledger entry
+type PointsUser struct {
+	Name   string
+	Points int
+	Karma  int
+
+	LastEarned time.Time
+	LastKarma  time.Time
+
+	// Temporary access level granted by a reward
+	Reward *PointsReward
+}
+
+// PointsReward stores a temporary access level and the level to restore once it expires
+type PointsReward struct {
+	Access  gateway.AccessLevel
+	Prev    gateway.AccessLevel
+	Expires time.Time
+}
+
+// PointsRank of user on gateway
+type PointsRank struct {
+	PointsUser
+	Gateway string
+	UserID  string
+}
+
+// must be called with ConfigMut held
+func (g *Goop) pointsUser(gid string, u *gateway.User) *PointsUser {
+	var conf = g.Config.GetPoints()
+	if conf.Users == nil {
+		conf.Users = make(map[string]map[string]*PointsUser)
+	}
+
+	var users = conf.Users[gid]
+	if users == nil {
+		users = make(map[string]*PointsUser)
+		conf.Users[gid] = users
+	}
+
+	var p = users[u.ID]
+	if p == nil {
+		p = &PointsUser{}
+		users[u.ID] = p
+	}
+
+	p.Name = u.Name
+	return p
+}
+
+// Points of user on gw
+func (g *Goop) Points(gw gateway.Gateway, u *gateway.User) PointsUser {
+	if g.Config == nil {
+		return PointsUser{Name: u.Name}
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	if p := g.Config.GetPoints().Users[gw.ID()][u.ID]; p != nil {
+		return *p
+	}
+	return PointsUser{Name: u.Name}
+}
+
+// AddPoints adds n (possibly negative) points to user on gw, returns the new balance
+func (g *Goop) AddPoints(gw gateway.Gateway, u *gateway.User, n int) (int, error) {
+	if g.Config == nil {
+		return 0, ErrPointsDisabled
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	if g.Config.GetPoints().Disabled {
+		return 0, ErrPointsDisabled
+	}
+
+	var p = g.pointsUser(gw.ID(), u)
+	if p.Points+n < 0 {
+		return p.Points, ErrNotEnoughPoints
+	}
+
+	p.Points += n
+	return p.Points, nil
+}
+
+// GivePoints transfers n points from user to another user on gw, returns the new balance of from
+func (g *Goop) GivePoints(gw gateway.Gateway, from *gateway.User, to *gateway.User, n int) (int, error) {
+	if g.Config == nil {
+		return 0, ErrPointsDisabled
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	if g.Config.GetPoints().Disabled {
+		return 0, ErrPointsDisabled
+	}
+
+	var src = g.pointsUser(gw.ID(), from)
+	if n <= 0 || src.Points < n {
+		return src.Points, ErrNotEnoughPoints
+	}
+
+	var dst = g.pointsUser(gw.ID(), to)
+	src.Points -= n
+	dst.Points += n
+	return src.Points, nil
+}
+
+// FindPoints returns the ledger entries of users on gateway gid that match pattern pat
+func (g *Goop) FindPoints(gid string, pat string) []*PointsRank {
+	if g.Config == nil {
+		return nil
+	}
+
+	pat = strings.ToLower(pat)
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var res = make([]*PointsRank, 0)
+	for uid, p := range g.Config.GetPoints().Users[gid] {
+		if p == nil {
+			continue
+		}
+		if !strings.EqualFold(uid, pat) {
+			if m, err := filepath.Match(pat, strings.ToLower(p.Name)); err != nil || !m {
+				continue
+			}
+		}
+		res = append(res, &PointsRank{
+			PointsUser: *p,
+			Gateway:    gid,
+			UserID:     uid,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Points > res[j].Points })
+	return res
+}
+
+// PointsTop returns the n users with the most points (or karma) on all gateways
+func (g *Goop) PointsTop(n int, karma bool) []*PointsRank {
+	if g.Config == nil {
+		return nil
+	}
+
+	var res = []*PointsRank{}
+
+	g.ConfigMut.Lock()
+	for gid, users := range g.Config.GetPoints().Users {
+		for uid, p := range users {
+			if p == nil {
+				continue
+			}
+			res = append(res, &PointsRank{
+				PointsUser: *p,
+				Gateway:    gid,
+				UserID:     uid,
+			})
+		}
+	}
+	g.ConfigMut.Unlock()
+
+	if karma {
+		sort.Slice(res, func(i, j int) bool { return res[i].Karma > res[j].Karma })
+	} else {
+		sort.Slice(res, func(i, j int) bool { return res[i].Points > res[j].Points })
+	}
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
+
+// GrantAccess temporarily sets access level of user on gw, until d has passed
+func (g *Goop) GrantAccess(gw gateway.Gateway, u *gateway.User, access gateway.AccessLevel, d time.Duration) error {
+	if g.Config == nil {
+		return ErrPointsDisabled
+	}
+
+	g.ConfigMut.Lock()
+	var p = g.pointsUser(gw.ID(), u)
+	if p.Reward != nil && p.Reward.Access == access {
+		// Extend running reward
+		p.Reward.Expires = p.Reward.Expires.Add(d)
+		g.ConfigMut.Unlock()
+		return nil
+	}
+	g.ConfigMut.Unlock()
+
+	// SetUserAccess fires events, so do not hold ConfigMut
+	prev, err := gw.SetUserAccess(u.ID, access)
+	if err != nil {
+		return err
+	}
+
+	var r = &PointsReward{
+		Access:  access,
+		Prev:    *prev,
+		Expires: time.Now().Add(d),
+	}
+
+	g.ConfigMut.Lock()
+	p = g.pointsUser(gw.ID(), u)
+	if p.Reward != nil {
+		// Restore original access level when the new reward expires
+		r.Prev = p.Reward.Prev
+	}
+	p.Reward = r
+	g.ConfigMut.Unlock()
+
+	return nil
+}
+
+// ExpireRewards restores the access level of users whose reward has expired
+func (g *Goop) ExpireRewards() {
+	if g.Config == nil {
+		return
+	}
+
+	type expired struct {
+		gw  gateway.Gateway
+		uid string
+		p   *PointsUser
+		r   *PointsReward
+	}
+
+	var now = time.Now()
+	var gws = g.Gateways()
+	var list []expired
+
+	g.ConfigMut.Lock()
+	for gid, users := range g.Config.GetPoints().Users {
+		var gw = gws[gid]
+		if gw == nil {
+			continue
+		}
+		for uid, p := range users {
+			if p == nil || p.Reward == nil || p.Reward.Expires.After(now) {
+				continue
+			}
+			list = append(list, expired{gw: gw, uid: uid, p: p, r: p.Reward})
+		}
+	}
+	g.ConfigMut.Unlock()
+
+	// SetUserAccess fires events, so do not hold ConfigMut
+	for _, e := range list {
+		// Only restore if access level was not changed in the meantime
+		if e.gw.Users()[e.uid] == e.r.Access {
+			if _, err := e.gw.SetUserAccess(e.uid, e.r.Prev); err != nil {
+				g.Fire(&network.AsyncError{Src: "ExpireRewards", Err: err})
+				continue
+			}
+		}
+
+		g.ConfigMut.Lock()
+		if e.p.Reward == e.r {
+			e.p.Reward = nil
+		}
+		g.ConfigMut.Unlock()
+	}
+}
+
+func (g *Goop) runRewards(ctx context.Context) {
+	if g.Config == nil {
+		return
+	}
+
+	var tick = time.NewTicker(time.Minute)
+	defer tick.Stop()
+
+	for {
+		g.ExpireRewards()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+var karmaPattern = regexp.MustCompile(`(?:^|\s)@?([^\s@+\-][^\s]*?)(\+\+|--)(?:\s|$)`)
+
+// KarmaMatch returns the name and karma delta of the first name++ (or name--) in msg
+func KarmaMatch(msg string) (string, int) {
+	var m = karmaPattern.FindStringSubmatch(msg)
+	if m == nil {
+		return "", 0
+	}
+	if m[2] == "--" {
+		return m[1], -1
+	}
+	return m[1], 1
+}
+
+func (g *Goop) updateKarma(gw gateway.Gateway, u *gateway.User, name string, delta int) {
+	var targets = gateway.FindUserInChannel(gw, name)
+	if len(targets) != 1 || targets[0].ID == u.ID {
+		return
+	}
+
+	var now = time.Now()
+
+	g.ConfigMut.Lock()
+	var conf = g.Config.GetPoints()
+	var src = g.pointsUser(gw.ID(), u)
+	if now.Sub(src.LastKarma) < conf.KarmaCooldown {
+		g.ConfigMut.Unlock()
+		return
+	}
+	src.LastKarma = now
+
+	var dst = g.pointsUser(gw.ID(), targets[0])
+	dst.Karma += delta
+	var karma = dst.Karma
+	g.ConfigMut.Unlock()
+
+	if err := gw.Say(fmt.Sprintf("%s has %d karma", targets[0].Name, karma)); err != nil {
+		g.Fire(&network.AsyncError{Src: "updateKarma", Err: err})
+	}
+}
+
+func (g *Goop) earnPoints(gw gateway.Gateway, u *gateway.User, msg string) {
+	var now = time.Now()
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetPoints()
+	if conf.PerMessage == 0 || len([]rune(strings.TrimSpace(msg))) < conf.MinLength {
+		return
+	}
+
+	var p = g.pointsUser(gw.ID(), u)
+	if now.Sub(p.LastEarned) < conf.Interval {
+		return
+	}
+
+	p.LastEarned = now
+	p.Points += conf.PerMessage
+}
+
+func (g *Goop) onPoints(ev *network.Event) {
+	var msg = ev.Arg.(*gateway.Chat)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok || g.Config == nil || msg.User.ID == "" || msg.User.Access <= gateway.AccessIgnore {
+		return
+	}
+
+	g.ConfigMut.Lock()
+	var disabled = g.Config.GetPoints().Disabled
+	g.ConfigMut.Unlock()
+	if disabled {
+		return
+	}
+
+	g.earnPoints(gw, &msg.User, msg.Content)
+
+	if name, delta := KarmaMatch(msg.Content); delta != 0 {
+		g.updateKarma(gw, &msg.User, name, delta)
+	}
+}
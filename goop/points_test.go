@@ -0,0 +1,101 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestGivePoints(t *testing.T) {
+	var alice = gateway.User{ID: "alice", Name: "Alice"}
+	var bob = gateway.User{ID: "bob", Name: "Bob"}
+
+	var g = goop.New(&testConfig{})
+	var gw = newTestGateway()
+	if err := g.AddGateway("test", gw); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.AddPoints(gw, &alice, 10); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{-1, 0, 11} {
+		if _, err := g.GivePoints(gw, &alice, &bob, n); err != goop.ErrNotEnoughPoints {
+			t.Fatalf("GivePoints(%d): expected ErrNotEnoughPoints, got %v", n, err)
+		}
+	}
+
+	n, err := g.GivePoints(gw, &alice, &bob, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 6 || g.Points(gw, &alice).Points != 6 || g.Points(gw, &bob).Points != 4 {
+		t.Fatalf("GivePoints: unexpected balance %d/%d", g.Points(gw, &alice).Points, g.Points(gw, &bob).Points)
+	}
+}
+
+func TestKarmaMatch(t *testing.T) {
+	var cases = map[string]struct {
+		name  string
+		delta int
+	}{
+		"bob++":            {"bob", 1},
+		"@bob--":           {"bob", -1},
+		"thanks bob++ !":   {"bob", 1},
+		"c++ is great":     {"c", 1},
+		"bob++b":           {"", 0},
+		"++":               {"", 0},
+		"i--; j++ in code": {"j", 1},
+		"nothing":          {"", 0},
+	}
+	for msg, c := range cases {
+		if name, delta := goop.KarmaMatch(msg); name != c.name || delta != c.delta {
+			t.Fatalf("%s: expected (%q, %d), got (%q, %d)", msg, c.name, c.delta, name, delta)
+		}
+	}
+}
+
+func TestGrantAccess(t *testing.T) {
+	var bob = gateway.User{ID: "bob", Name: "Bob", Access: gateway.AccessVoice}
+
+	var g = goop.New(&testConfig{})
+	var gw = newTestGateway(bob)
+	if err := g.AddGateway("test", gw); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.GrantAccess(gw, &bob, gateway.AccessWhitelist, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if gw.Users()[bob.ID] != gateway.AccessWhitelist {
+		t.Fatalf("GrantAccess: expected access to be granted, got %v", gw.Users()[bob.ID])
+	}
+
+	var r = g.Points(gw, &bob).Reward
+	if r == nil || r.Prev != gateway.AccessVoice {
+		t.Fatalf("GrantAccess: expected reward to restore <voice>, got %v", r)
+	}
+
+	// Granting the same access level again extends the reward
+	var exp = r.Expires
+	if err := g.GrantAccess(gw, &bob, gateway.AccessWhitelist, -3*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if r = g.Points(gw, &bob).Reward; r == nil || !r.Expires.Equal(exp.Add(-3*time.Hour)) {
+		t.Fatalf("GrantAccess: expected reward to be extended, got %v", r)
+	}
+
+	g.ExpireRewards()
+	if gw.Users()[bob.ID] != gateway.AccessVoice {
+		t.Fatalf("ExpireRewards: expected access to be restored, got %v", gw.Users()[bob.ID])
+	}
+	if r = g.Points(gw, &bob).Reward; r != nil {
+		t.Fatalf("ExpireRewards: expected reward to be removed, got %v", r)
+	}
+}
@@ -0,0 +1,233 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrNoPoll         = errors.New("goop: No active poll")
+	ErrPollOptions    = errors.New("goop: Invalid number of poll options")
+	ErrInvalidOption  = errors.New("goop: Invalid poll option")
+	ErrDuplicateVoter = errors.New("goop: Already voted")
+)
+
+// PollMaxOptions is the maximum number of options in a poll
+const PollMaxOptions = 10
+
+// PollGateway is optionally implemented by gateways that support voting natively (i.e. Discord reactions)
+// vote is called with the (zero-based) option for each vote and returns false when the poll is closed
+// closed is closed once the poll no longer accepts votes
+type PollGateway interface {
+	SayPoll(s string, options int, vote func(uid string, option int) bool, closed <-chan struct{}) error
+}
+
+// Poll is a vote between options, open to the origin gateway and every gateway it relays Say events to
+type Poll struct {
+	ID       int
+	Question string
+	Options  []string
+	Access   gateway.AccessLevel
+	Origin   gateway.Gateway
+	Creator  gateway.User
+	Ends     time.Time
+
+	mut    sync.Mutex
+	goop   *Goop
+	closed bool
+	done   chan struct{}
+	votes  map[string]int
+	timer  *time.Timer
+}
+
+type polls struct {
+	mut    sync.Mutex
+	nextID int
+	active []*Poll
+}
+
+// Tally counts the votes per option
+func (p *Poll) Tally() []int {
+	var res = make([]int, len(p.Options))
+
+	p.mut.Lock()
+	for _, o := range p.votes {
+		res[o]++
+	}
+	p.mut.Unlock()
+
+	return res
+}
+
+// Closed returns true if poll no longer accepts votes
+func (p *Poll) Closed() bool {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return p.closed
+}
+
+// Vote for option (zero-based) as user u on gw, accounts linked to the same identity share a single vote
+func (p *Poll) Vote(gw gateway.Gateway, u *gateway.User, option int) error {
+	if u.Access < p.Access {
+		return gateway.ErrNoPermission
+	}
+	if option < 0 || option >= len(p.Options) {
+		return ErrInvalidOption
+	}
+
+	var key = gw.ID() + gateway.Delimiter + u.ID
+	if p.goop != nil {
+		key = p.goop.IdentityKey(gw.ID(), u.ID)
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.closed {
+		return ErrNoPoll
+	}
+	if _, ok := p.votes[key]; ok {
+		return ErrDuplicateVoter
+	}
+
+	p.votes[key] = option
+	return nil
+}
+
+func (p *Poll) String() string {
+	var s = make([]string, len(p.Options))
+	for i, o := range p.Options {
+		s[i] = fmt.Sprintf("[%d] %s", i+1, o)
+	}
+	return fmt.Sprintf("Poll #%d by %s: %s %s", p.ID, p.Creator.Name, p.Question, strings.Join(s, " "))
+}
+
+// Result of poll
+func (p *Poll) Result() string {
+	var t = p.Tally()
+	var n = 0
+	var s = make([]string, len(p.Options))
+	for i, o := range p.Options {
+		n += t[i]
+		s[i] = fmt.Sprintf("%s: %d", o, t[i])
+	}
+	return fmt.Sprintf("Poll #%d: %s %s (%d votes)", p.ID, p.Question, strings.Join(s, ", "), n)
+}
+
+// audience returns the gateways that receive Say events from origin
+func (g *Goop) audience(origin gateway.Gateway) []gateway.Gateway {
+	var res = []gateway.Gateway{origin}
+	for id, gw := range g.Gateways() {
+		if id == origin.ID() || gw.Channel() == nil {
+			continue
+		}
+		if r := g.Relay()[id][origin.ID()]; r != nil && r.Say {
+			res = append(res, gw)
+		}
+	}
+	return res
+}
+
+func (g *Goop) announcePoll(p *Poll, s string, native bool) {
+	for _, gw := range g.audience(p.Origin) {
+		var err error
+		if pg, ok := gw.(PollGateway); ok && native {
+			var target = gw
+			err = pg.SayPoll(s, len(p.Options), func(uid string, option int) bool {
+				if u, err := target.User(uid); err == nil && u != nil {
+					p.Vote(target, u, option)
+				}
+				return !p.Closed()
+			}, p.done)
+		} else {
+			var msg = s
+			if native {
+				msg += fmt.Sprintf(" (vote with %svote [number])", gw.Trigger())
+			}
+			err = gw.Relay(&network.Event{Arg: &gateway.SystemMessage{Type: "POLL", Content: msg}}, p.Origin)
+		}
+		if err != nil && !network.IsCloseError(err) {
+			gw.Fire(&network.AsyncError{Src: "announcePoll", Err: err})
+		}
+	}
+}
+
+// StartPoll opens p for voting on its origin gateway and all gateways it relays to, and closes it after d
+func (g *Goop) StartPoll(p *Poll, d time.Duration) error {
+	if len(p.Options) < 2 || len(p.Options) > PollMaxOptions {
+		return ErrPollOptions
+	}
+
+	p.goop = g
+	p.done = make(chan struct{})
+	p.votes = make(map[string]int)
+	p.Ends = time.Now().Add(d)
+
+	g.polls.mut.Lock()
+	g.polls.nextID++
+	p.ID = g.polls.nextID
+	g.polls.active = append(g.polls.active, p)
+	g.polls.mut.Unlock()
+
+	p.mut.Lock()
+	p.timer = time.AfterFunc(d, func() { g.ClosePoll(p) })
+	p.mut.Unlock()
+
+	g.announcePoll(p, p.String(), true)
+	return nil
+}
+
+// ClosePoll stops p from accepting votes and announces the result
+func (g *Goop) ClosePoll(p *Poll) {
+	p.mut.Lock()
+	if p.closed {
+		p.mut.Unlock()
+		return
+	}
+	p.closed = true
+	if p.done != nil {
+		close(p.done)
+	}
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.mut.Unlock()
+
+	g.polls.mut.Lock()
+	for i, a := range g.polls.active {
+		if a == p {
+			g.polls.active = append(g.polls.active[:i], g.polls.active[i+1:]...)
+			break
+		}
+	}
+	g.polls.mut.Unlock()
+
+	g.announcePoll(p, "Closed "+p.Result(), false)
+}
+
+// ActivePoll returns the most recent poll that is open to gw
+func (g *Goop) ActivePoll(gw gateway.Gateway) *Poll {
+	g.polls.mut.Lock()
+	var active = append([]*Poll{}, g.polls.active...)
+	g.polls.mut.Unlock()
+
+	for i := len(active) - 1; i >= 0; i-- {
+		for _, a := range g.audience(active[i].Origin) {
+			if a == gw {
+				return active[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestPollIdentity(t *testing.T) {
+	var conf = &testConfig{}
+	conf.Identity.Users = map[string]map[string]string{
+		"bnet":    {"niels": "Niels"},
+		"discord": {"123": "Niels"},
+	}
+
+	var g = goop.New(conf)
+	var bnet = newTestGateway()
+	var discord = newTestGateway()
+	if err := g.AddGateway("bnet", bnet); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddGateway("discord", discord); err != nil {
+		t.Fatal(err)
+	}
+
+	var p = &goop.Poll{Question: "?", Options: []string{"a", "b"}, Origin: bnet}
+	if err := g.StartPoll(p, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	defer g.ClosePoll(p)
+
+	if err := p.Vote(bnet, &gateway.User{ID: "niels"}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Vote(discord, &gateway.User{ID: "123"}, 1); err != goop.ErrDuplicateVoter {
+		t.Fatalf("Expected ErrDuplicateVoter for linked account, got %v", err)
+	}
+	if err := p.Vote(discord, &gateway.User{ID: "niels"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if tally := p.Tally(); tally[0] != 1 || tally[1] != 1 {
+		t.Fatalf("Unexpected tally %v", tally)
+	}
+}
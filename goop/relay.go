@@ -22,6 +22,9 @@ type RelayConfig struct {
 	JoinAccess        gateway.AccessLevel
 	ChatAccess        gateway.AccessLevel
 	PrivateChatAccess gateway.AccessLevel
+
+	// Number of recent chat messages relayed after From (re)connects
+	History int
 }
 
 // Relay manages a relay between two gateways
@@ -30,6 +33,8 @@ type Relay struct {
 	To   gateway.Gateway
 
 	*RelayConfig
+
+	handlers []network.EventID
 }
 
 // NewRelay initializes a new GatRelayeway struct
@@ -45,18 +50,28 @@ func NewRelay(from, to gateway.Gateway, conf *RelayConfig) *Relay {
 
 // InitDefaultHandlers adds the default callbacks for relevant events
 func (r *Relay) InitDefaultHandlers() {
-	r.From.On(&network.AsyncError{}, r.onLog)
-	r.From.On(&gateway.Connected{}, r.onLog)
-	r.From.On(&gateway.Disconnected{}, r.onLog)
-	r.From.On(&gateway.Channel{}, r.onChannel)
-	r.From.On(&gateway.SystemMessage{}, r.onSystemMessage)
-	r.From.On(&gateway.Clear{}, r.onClear)
-	r.From.On(&gateway.Join{}, r.onJoin)
-	r.From.On(&gateway.User{}, r.onUser)
-	r.From.On(&gateway.Leave{}, r.onLeave)
-	r.From.On(&gateway.Chat{}, r.onChat)
-	r.From.On(&gateway.PrivateChat{}, r.onPrivateChat)
-	r.From.On(&gateway.Say{}, r.onSay)
+	r.handlers = append(r.handlers,
+		r.From.On(&network.AsyncError{}, r.onLog),
+		r.From.On(&gateway.Connected{}, r.onLog),
+		r.From.On(&gateway.Disconnected{}, r.onLog),
+		r.From.On(&gateway.Channel{}, r.onChannel),
+		r.From.On(&gateway.SystemMessage{}, r.onSystemMessage),
+		r.From.On(&gateway.Clear{}, r.onClear),
+		r.From.On(&gateway.Join{}, r.onJoin),
+		r.From.On(&gateway.User{}, r.onUser),
+		r.From.On(&gateway.Leave{}, r.onLeave),
+		r.From.On(&gateway.Chat{}, r.onChat),
+		r.From.On(&gateway.PrivateChat{}, r.onPrivateChat),
+		r.From.On(&gateway.Say{}, r.onSay),
+	)
+}
+
+// Close removes the callbacks added by InitDefaultHandlers
+func (r *Relay) Close() {
+	for _, h := range r.handlers {
+		r.From.Off(h)
+	}
+	r.handlers = nil
 }
 
 func (r *Relay) relay(ev *network.Event) {
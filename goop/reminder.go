@@ -0,0 +1,215 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrReminderLimit = errors.New("goop: Too many pending reminders")
+)
+
+// MaxReminderRetries is the number of times delivery of a reminder is retried before it is dropped
+const MaxReminderRetries = 10
+
+// ReminderConfig stores pending reminders
+type ReminderConfig struct {
+	Reminders []*Reminder
+}
+
+// Reminder is a message that is delivered to a user at a specific time
+type Reminder struct {
+	Gateway     string
+	To          string
+	ToName      string
+	From        string
+	FromID      string
+	FromGateway string
+	Message     string
+	Created     time.Time
+	Due         time.Time
+	Retries     int
+}
+
+func (r *Reminder) sentBy(gw string, uid string) bool {
+	return r.FromGateway == gw && r.FromID == uid
+}
+
+func (r *Reminder) String() string {
+	var ago = time.Since(r.Created).Round(time.Second)
+	if r.Gateway == r.FromGateway && r.To == r.FromID {
+		return fmt.Sprintf("Reminder (set %s ago): %s", ago, r.Message)
+	}
+	return fmt.Sprintf("Reminder from %s (set %s ago): %s", r.From, ago, r.Message)
+}
+
+// AddReminder stores r until it is due, fails if sender already has limit pending reminders
+func (g *Goop) AddReminder(r *Reminder, limit int) error {
+	if g.Config == nil {
+		return ErrReminderLimit
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetReminder()
+	if limit > 0 {
+		var n = 0
+		for _, p := range conf.Reminders {
+			if p.sentBy(r.FromGateway, r.FromID) {
+				n++
+			}
+		}
+		if n >= limit {
+			return ErrReminderLimit
+		}
+	}
+
+	if r.Created.IsZero() {
+		r.Created = time.Now()
+	}
+
+	conf.Reminders = append(conf.Reminders, r)
+	return nil
+}
+
+// PendingReminders returns the reminders set by uid on gw, first due first
+func (g *Goop) PendingReminders(gw string, uid string) []Reminder {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var res = make([]Reminder, 0)
+	for _, r := range g.Config.GetReminder().Reminders {
+		if r.sentBy(gw, uid) {
+			res = append(res, *r)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Due.Before(res[j].Due) })
+	return res
+}
+
+// CancelReminder deletes the n-th (1-based, as listed by PendingReminders) reminder set by uid on gw
+func (g *Goop) CancelReminder(gw string, uid string, n int) *Reminder {
+	var p = g.PendingReminders(gw, uid)
+	if n < 1 || n > len(p) {
+		return nil
+	}
+
+	// Only take one, in case of identical reminders
+	var target = p[n-1]
+	var taken = false
+	var res = g.takeReminders(func(r *Reminder) bool {
+		if taken || !r.sentBy(gw, uid) || *r != target {
+			return true
+		}
+		taken = true
+		return false
+	})
+	if len(res) == 0 {
+		return nil
+	}
+	return res[0]
+}
+
+// takeReminders removes and returns all reminders for which keep returns false
+func (g *Goop) takeReminders(keep func(r *Reminder) bool) []*Reminder {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetReminder()
+	var res []*Reminder
+	var rem = conf.Reminders[:0]
+	for _, r := range conf.Reminders {
+		if keep(r) {
+			rem = append(rem, r)
+		} else {
+			res = append(res, r)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+
+	for i := len(rem); i < len(conf.Reminders); i++ {
+		conf.Reminders[i] = nil
+	}
+	conf.Reminders = rem
+	return res
+}
+
+// deliverReminder privately, or in channel if that fails
+func (g *Goop) deliverReminder(r *Reminder) error {
+	var gw = g.Gateways()[r.Gateway]
+	if gw == nil {
+		return ErrUnknownGateway
+	}
+
+	var msg = r.String()
+	if err := gw.SayPrivate(r.To, msg); err == nil {
+		return nil
+	}
+	return gw.Say(fmt.Sprintf("%s: %s", r.ToName, msg))
+}
+
+func (g *Goop) runReminders(ctx context.Context) {
+	if g.Config == nil {
+		return
+	}
+
+	var tick = time.NewTicker(time.Second)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+
+		var now = time.Now()
+		var due = g.takeReminders(func(r *Reminder) bool {
+			return r.Due.After(now)
+		})
+
+		for _, r := range due {
+			var err = g.deliverReminder(r)
+			if err == nil {
+				continue
+			}
+
+			g.Fire(&network.AsyncError{Src: "runReminders", Err: err})
+			if r.Retries >= MaxReminderRetries {
+				continue
+			}
+
+			// Try again later
+			var retry = *r
+			retry.Due = now.Add(time.Minute)
+			retry.Retries++
+
+			g.ConfigMut.Lock()
+			var conf = g.Config.GetReminder()
+			conf.Reminders = append(conf.Reminders, &retry)
+			g.ConfigMut.Unlock()
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestCancelReminder(t *testing.T) {
+	var g = goop.New(&testConfig{})
+
+	var now = time.Now()
+	var add = func(uid string, msg string, due time.Duration) {
+		var r = &goop.Reminder{
+			Gateway:     "test",
+			To:          uid,
+			FromID:      uid,
+			FromGateway: "test",
+			Message:     msg,
+			Created:     now,
+			Due:         now.Add(due),
+		}
+		if err := g.AddReminder(r, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	add("alice", "c", 3*time.Hour)
+	add("alice", "a", time.Hour)
+	add("bob", "a", time.Hour)
+	add("alice", "b", 2*time.Hour)
+	add("alice", "b", 2*time.Hour)
+
+	for _, n := range []int{-1, 0, 5} {
+		if r := g.CancelReminder("test", "alice", n); r != nil {
+			t.Fatalf("CancelReminder(%d): expected nil, got %v", n, r)
+		}
+	}
+
+	// Identical reminders are cancelled one at a time
+	if r := g.CancelReminder("test", "alice", 2); r == nil || r.Message != "b" {
+		t.Fatalf("CancelReminder(2): expected b, got %v", r)
+	}
+	var p = g.PendingReminders("test", "alice")
+	if len(p) != 3 || p[0].Message != "a" || p[1].Message != "b" || p[2].Message != "c" {
+		t.Fatalf("PendingReminders: unexpected %v", p)
+	}
+
+	if r := g.CancelReminder("test", "alice", 1); r == nil || r.Message != "a" {
+		t.Fatalf("CancelReminder(1): expected a, got %v", r)
+	}
+	if p := g.PendingReminders("test", "bob"); len(p) != 1 {
+		t.Fatalf("PendingReminders(bob): expected 1 reminder, got %v", p)
+	}
+}
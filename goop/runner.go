@@ -0,0 +1,193 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrUnknownGateway    = errors.New("goop: Unknown gateway")
+	ErrGatewayRunning    = errors.New("goop: Gateway already running")
+	ErrGatewayNotRunning = errors.New("goop: Gateway not running")
+	ErrNotStarted        = errors.New("goop: Not started")
+)
+
+// ChannelJoiner is implemented by gateways that can switch channels at runtime
+type ChannelJoiner interface {
+	JoinChannel(name string) error
+}
+
+type runner struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// Cancelled, but gw.Run has not returned yet
+	stopping bool
+}
+
+type runners struct {
+	mut sync.Mutex
+	ctx context.Context
+	gws map[string]*runner
+	wg  sync.WaitGroup
+}
+
+// start runs gw in a new goroutine after prev has finished, must be called with runners.mut held
+func (g *Goop) start(id string, gw gateway.Gateway, prev *runner) {
+	var ctx, cancel = context.WithCancel(g.runners.ctx)
+	var r = &runner{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	g.runners.gws[id] = r
+	g.runners.wg.Add(1)
+
+	go func() {
+		if prev != nil {
+			<-prev.done
+		}
+		if err := gw.Run(ctx); err != nil && err != context.Canceled {
+			g.Fire(&network.AsyncError{Src: fmt.Sprintf("Run[gw:%s]", id), Err: err})
+		}
+		cancel()
+
+		g.runners.mut.Lock()
+		if g.runners.gws[id] == r {
+			delete(g.runners.gws, id)
+		}
+		g.runners.mut.Unlock()
+
+		close(r.done)
+		g.runners.wg.Done()
+	}()
+}
+
+// Running returns true if gateway id is running
+func (g *Goop) Running(id string) bool {
+	g.runners.mut.Lock()
+	defer g.runners.mut.Unlock()
+	var r = g.runners.gws[id]
+	return r != nil && !r.stopping
+}
+
+// Connect starts running gateway id, after it has finished stopping if it was disconnected
+func (g *Goop) Connect(id string) error {
+	var gw = g.Gateways()[id]
+	if gw == nil {
+		return ErrUnknownGateway
+	}
+
+	g.runners.mut.Lock()
+	defer g.runners.mut.Unlock()
+
+	if g.runners.ctx == nil || g.runners.ctx.Err() != nil {
+		return ErrNotStarted
+	}
+	var r = g.runners.gws[id]
+	if r != nil && !r.stopping {
+		return ErrGatewayRunning
+	}
+
+	g.start(id, gw, r)
+	return nil
+}
+
+// Disconnect stops running gateway id, does not wait for it to finish
+func (g *Goop) Disconnect(id string) error {
+	if g.Gateways()[id] == nil {
+		return ErrUnknownGateway
+	}
+	return g.disconnect(id)
+}
+
+func (g *Goop) disconnect(id string) error {
+	g.runners.mut.Lock()
+	defer g.runners.mut.Unlock()
+
+	var r = g.runners.gws[id]
+	if r == nil || r.stopping {
+		return ErrGatewayNotRunning
+	}
+
+	// Keep runner until it has finished, so that Connect can wait for it
+	r.stopping = true
+	r.cancel()
+	return nil
+}
+
+// Reconnect stops gateway id (if running) and starts it again once it has finished
+func (g *Goop) Reconnect(id string) error {
+	var gw = g.Gateways()[id]
+	if gw == nil {
+		return ErrUnknownGateway
+	}
+
+	g.runners.mut.Lock()
+	defer g.runners.mut.Unlock()
+
+	if g.runners.ctx == nil || g.runners.ctx.Err() != nil {
+		return ErrNotStarted
+	}
+
+	var r = g.runners.gws[id]
+	if r != nil {
+		r.cancel()
+	}
+
+	g.start(id, gw, r)
+	return nil
+}
+
+// FindGateways returns the sorted IDs of gateways matching pattern pat
+func (g *Goop) FindGateways(pat string) []string {
+	var res = []string{}
+	pat = strings.ToLower(pat)
+	for id := range g.Gateways() {
+		if ok, err := filepath.Match(pat, strings.ToLower(id)); err != nil || !ok {
+			continue
+		}
+		res = append(res, id)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Run connects all gateways and blocks until ctx is done and every gateway has stopped
+func (g *Goop) Run(ctx context.Context) {
+	g.Fire(Start{})
+	g.SyncCommands()
+	go g.runSchedule(ctx)
+	go g.runRewards(ctx)
+	go g.runReminders(ctx)
+
+	g.runners.mut.Lock()
+	g.runners.ctx = ctx
+	g.runners.gws = make(map[string]*runner)
+	for id, gw := range g.Gateways() {
+		g.start(id, gw, nil)
+	}
+	g.runners.mut.Unlock()
+
+	// Keep running when all gateways are disconnected, they can be connected again
+	<-ctx.Done()
+
+	g.runners.mut.Lock()
+	g.runners.ctx = nil
+	g.runners.mut.Unlock()
+
+	g.runners.wg.Wait()
+	g.Fire(Stop{})
+}
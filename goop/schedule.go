@@ -0,0 +1,203 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrInvalidSchedule = errors.New("goop: Expected cron expression or interval")
+	ErrInvalidTrigger  = errors.New("goop: Invalid trigger")
+)
+
+// ScheduleConfig maps names to scheduled triggers
+type ScheduleConfig map[string]*ScheduleEntry
+
+// ScheduleEntry executes Trigger on each gateway that matches Gateway pattern,
+// either when Cron matches or every Interval
+type ScheduleEntry struct {
+	Cron     string
+	Interval time.Duration
+	Gateway  string
+	Trigger  string
+	Access   gateway.AccessLevel
+}
+
+// Validate schedule entry
+func (s *ScheduleEntry) Validate() error {
+	if (s.Cron == "") == (s.Interval <= 0) {
+		return ErrInvalidSchedule
+	}
+	if s.Cron != "" {
+		if _, err := ParseCron(s.Cron); err != nil {
+			return err
+		}
+	}
+	if gateway.ExtractTrigger(s.Trigger) == nil {
+		return ErrInvalidTrigger
+	}
+	return nil
+}
+
+func (s *ScheduleEntry) String() string {
+	var when = s.Cron
+	if when == "" {
+		when = "every " + s.Interval.String()
+	}
+	return fmt.Sprintf("[%s] on %s as %s: %s", when, s.Gateway, s.Access, s.Trigger)
+}
+
+// AddSchedule adds (or replaces) entry name
+func (g *Goop) AddSchedule(name string, s *ScheduleEntry) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	g.ConfigMut.Lock()
+	var conf = g.Config.GetSchedule()
+	if *conf == nil {
+		*conf = make(ScheduleConfig)
+	}
+	(*conf)[strings.ToLower(name)] = s
+	g.ConfigMut.Unlock()
+
+	g.Fire(&gateway.ConfigUpdate{})
+	return nil
+}
+
+// RemoveSchedule deletes entry name, returns false if it did not exist
+func (g *Goop) RemoveSchedule(name string) bool {
+	name = strings.ToLower(name)
+
+	g.ConfigMut.Lock()
+	var conf = g.Config.GetSchedule()
+	var _, ok = (*conf)[name]
+	delete(*conf, name)
+	g.ConfigMut.Unlock()
+
+	if ok {
+		g.Fire(&gateway.ConfigUpdate{})
+	}
+	return ok
+}
+
+// Schedule returns a copy of all scheduled entries
+func (g *Goop) Schedule() map[string]ScheduleEntry {
+	if g.Config == nil {
+		return nil
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var res = make(map[string]ScheduleEntry)
+	for k, s := range *g.Config.GetSchedule() {
+		if s != nil {
+			res[k] = *s
+		}
+	}
+	return res
+}
+
+// execSchedule executes entry s on all matching gateways
+func (g *Goop) execSchedule(name string, s *ScheduleEntry) {
+	var p = strings.ToLower(s.Gateway)
+	for k, gw := range g.Gateways() {
+		if ok, err := filepath.Match(p, strings.ToLower(k)); err != nil || !ok {
+			continue
+		}
+		if gw.Channel() == nil {
+			continue
+		}
+
+		var trig = gateway.ExtractTrigger(gateway.TrimTrigger(s.Trigger, gateway.Triggers(gw)...))
+		if trig == nil {
+			continue
+		}
+
+		var target = gw
+		// Stable ID, so cooldowns apply per entry
+		var id = "schedule" + gateway.Delimiter + name
+		trig.User = gateway.User{ID: id, Name: id, Access: s.Access}
+		trig.Resp = target.Say
+
+		go func() {
+			if err := g.execChain(stages(trig, target), target); err != nil {
+				g.Fire(&network.AsyncError{Src: fmt.Sprintf("schedule[%s]", name), Err: err})
+			}
+		}()
+	}
+}
+
+// runSchedule executes scheduled entries until ctx is done
+func (g *Goop) runSchedule(ctx context.Context) {
+	if g.Config == nil {
+		return
+	}
+
+	var next = make(map[string]time.Time)
+	var cron = make(map[string]*Cron)
+	var last = time.Now().Truncate(time.Minute)
+
+	var tick = time.NewTicker(time.Second)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+
+		var now = time.Now()
+		var minute = now.Truncate(time.Minute)
+
+		for name, s := range g.Schedule() {
+			var e = s
+			if e.Interval > 0 {
+				var n, ok = next[name]
+				if !ok || n.Sub(now) > e.Interval {
+					// New (or shortened) interval
+					next[name] = now.Add(e.Interval)
+					continue
+				}
+				if now.Before(n) {
+					continue
+				}
+				next[name] = now.Add(e.Interval)
+				g.execSchedule(name, &e)
+				continue
+			}
+
+			if !minute.After(last) || e.Cron == "" {
+				continue
+			}
+
+			var c, ok = cron[e.Cron]
+			if !ok {
+				var err error
+				if c, err = ParseCron(e.Cron); err != nil {
+					g.Fire(&network.AsyncError{Src: fmt.Sprintf("schedule[%s]", name), Err: err})
+				}
+				cron[e.Cron] = c
+			}
+			if c != nil && c.Match(minute) {
+				g.execSchedule(name, &e)
+			}
+		}
+
+		last = minute
+	}
+}
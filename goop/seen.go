@@ -0,0 +1,141 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// SeenConfig stores user activity per gateway and user ID
+type SeenConfig struct {
+	Disabled bool
+	Users    map[string]map[string]*SeenUser
+}
+
+// SeenUser stores the last known activity of a user
+type SeenUser struct {
+	Name      string
+	Access    gateway.AccessLevel
+	Channel   string
+	Message   string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Seen activity of user on gateway
+type Seen struct {
+	SeenUser
+	Gateway string
+	UserID  string
+}
+
+func (g *Goop) updateSeen(gw gateway.Gateway, u *gateway.User, msg *string) {
+	if g.Config == nil || u.ID == "" {
+		return
+	}
+
+	var now = time.Now()
+	var channel string
+	if c := gw.Channel(); c != nil {
+		channel = c.Name
+	}
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetSeen()
+	if conf == nil || conf.Disabled {
+		return
+	}
+	if conf.Users == nil {
+		conf.Users = make(map[string]map[string]*SeenUser)
+	}
+
+	var users = conf.Users[gw.ID()]
+	if users == nil {
+		users = make(map[string]*SeenUser)
+		conf.Users[gw.ID()] = users
+	}
+
+	var s = users[u.ID]
+	if s == nil {
+		s = &SeenUser{FirstSeen: now}
+		users[u.ID] = s
+	}
+
+	s.Name = u.Name
+	s.Access = u.Access
+	s.LastSeen = now
+	if channel != "" {
+		s.Channel = channel
+	}
+	if msg != nil {
+		s.Message = *msg
+	}
+}
+
+func (g *Goop) onSeen(ev *network.Event) {
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok {
+		return
+	}
+
+	switch v := ev.Arg.(type) {
+	case *gateway.Join:
+		g.updateSeen(gw, &v.User, nil)
+	case *gateway.Leave:
+		g.updateSeen(gw, &v.User, nil)
+	case *gateway.Chat:
+		g.updateSeen(gw, &v.User, &v.Content)
+	case *gateway.PrivateChat:
+		// Do not store private messages
+		g.updateSeen(gw, &v.User, nil)
+	}
+}
+
+// FindSeen returns the activity of users that match pattern pat on all gateways, most recent first
+func (g *Goop) FindSeen(pat string) []*Seen {
+	if g.Config == nil {
+		return nil
+	}
+
+	pat = strings.ToLower(pat)
+
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	var conf = g.Config.GetSeen()
+	if conf == nil {
+		return nil
+	}
+
+	var res = make([]*Seen, 0)
+	for gid, users := range conf.Users {
+		for uid, u := range users {
+			if !strings.EqualFold(uid, pat) {
+				if m, err := filepath.Match(pat, strings.ToLower(u.Name)); err != nil || !m {
+					continue
+				}
+			}
+			res = append(res, &Seen{
+				SeenUser: *u,
+				Gateway:  gid,
+				UserID:   uid,
+			})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].LastSeen.After(res[j].LastSeen)
+	})
+
+	return res
+}
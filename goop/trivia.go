@@ -0,0 +1,491 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// Errors
+var (
+	ErrTriviaFormat  = errors.New("goop: Unknown trivia pack format")
+	ErrNoQuestions   = errors.New("goop: No trivia questions")
+	ErrTriviaRunning = errors.New("goop: Trivia already running")
+	ErrNoTrivia      = errors.New("goop: No active trivia")
+)
+
+// TriviaConfig stores the trivia scoreboard per gateway and user ID, scores of linked accounts are combined per identity
+type TriviaConfig struct {
+	Scores map[string]map[string]*TriviaScore
+}
+
+// TriviaScore of a single user
+type TriviaScore struct {
+	Name    string
+	Points  int
+	Answers int
+}
+
+// TriviaRank is an entry in the trivia scoreboard
+// Gateway and UserID are empty if the entry combines the accounts linked to Identity
+type TriviaRank struct {
+	TriviaScore
+	Gateway  string
+	UserID   string
+	Identity string
+}
+
+// TriviaQuestion with one or more accepted answers
+type TriviaQuestion struct {
+	Question string
+	Answers  []string
+}
+
+// TriviaPack is the file format of a list of questions
+type TriviaPack struct {
+	Questions []TriviaQuestion
+}
+
+// LoadTriviaPack reads questions from a JSON or TOML file
+func LoadTriviaPack(file string) ([]TriviaQuestion, error) {
+	var pack TriviaPack
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &pack); err != nil {
+			return nil, err
+		}
+	case ".toml":
+		if _, err := toml.DecodeFile(file, &pack); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrTriviaFormat
+	}
+
+	var res = make([]TriviaQuestion, 0, len(pack.Questions))
+	for _, q := range pack.Questions {
+		if q.Question != "" && len(q.Answers) > 0 {
+			res = append(res, q)
+		}
+	}
+	return res, nil
+}
+
+func normalizeAnswer(s string) []rune {
+	var res = []rune{}
+	var space = true
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			res = append(res, r)
+			space = false
+		case unicode.IsSpace(r) && !space:
+			res = append(res, ' ')
+			space = true
+		}
+	}
+	if space && len(res) > 0 {
+		res = res[:len(res)-1]
+	}
+	return res
+}
+
+func levenshtein(a, b []rune) int {
+	var prev = make([]int, len(b)+1)
+	var curr = make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			var cost = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if curr[j] > prev[j]+1 {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j] > curr[j-1]+1 {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// FuzzyMatch returns true if guess is close enough to answer
+// Case, punctuation, and one typo per five characters are ignored
+func FuzzyMatch(answer string, guess string) bool {
+	var a = normalizeAnswer(answer)
+	var b = normalizeAnswer(guess)
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	return levenshtein(a, b) <= len(a)/5
+}
+
+// Hint for answer with the first n of parts letters revealed
+func Hint(answer string, n int, parts int) string {
+	var r = []rune(answer)
+	var letters = 0
+	for _, c := range r {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			letters++
+		}
+	}
+
+	var reveal = letters * n / parts
+	var res = make([]rune, len(r))
+	for i, c := range r {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			res[i] = c
+		} else if reveal > 0 {
+			res[i] = c
+			reveal--
+		} else {
+			res[i] = '_'
+		}
+	}
+	return string(res)
+}
+
+// Trivia game, open to the origin gateway and every gateway it relays Say events to
+type Trivia struct {
+	Origin    gateway.Gateway
+	Questions []TriviaQuestion
+
+	// Number of questions to ask (0 for all)
+	Count int
+
+	// Time to answer a question, and number of hints given in that time
+	Timeout time.Duration
+	Hints   int
+
+	mut     sync.Mutex
+	current *TriviaQuestion
+	winner  chan triviaAnswer
+	stop    chan struct{}
+	stopped bool
+	scores  map[string]*TriviaRank
+}
+
+type triviaAnswer struct {
+	gw   gateway.Gateway
+	user gateway.User
+}
+
+type trivias struct {
+	mut    sync.Mutex
+	active map[string]*Trivia
+}
+
+// Answer question as user u on gw, returns true if the answer is correct
+func (t *Trivia) Answer(gw gateway.Gateway, u *gateway.User, s string) bool {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	if t.current == nil {
+		return false
+	}
+	for _, a := range t.current.Answers {
+		if FuzzyMatch(a, s) {
+			t.current = nil
+			t.winner <- triviaAnswer{gw: gw, user: *u}
+			return true
+		}
+	}
+	return false
+}
+
+// Stop game, revealing the answer to the current question
+func (t *Trivia) Stop() {
+	t.mut.Lock()
+	if !t.stopped {
+		t.stopped = true
+		close(t.stop)
+	}
+	t.mut.Unlock()
+}
+
+func (g *Goop) sayTrivia(t *Trivia, s string) {
+	for _, gw := range g.audience(t.Origin) {
+		var err = gw.Relay(&network.Event{Arg: &gateway.SystemMessage{Type: "TRIVIA", Content: s}}, t.Origin)
+		if err != nil && !network.IsCloseError(err) {
+			gw.Fire(&network.AsyncError{Src: "sayTrivia", Err: err})
+		}
+	}
+}
+
+// StartTrivia asks the questions of t (in random order) on its origin gateway and all gateways it relays to
+func (g *Goop) StartTrivia(t *Trivia) error {
+	if len(t.Questions) == 0 {
+		return ErrNoQuestions
+	}
+	if t.Timeout <= 0 {
+		t.Timeout = 30 * time.Second
+	}
+	if t.Hints < 0 {
+		t.Hints = 0
+	}
+
+	g.trivias.mut.Lock()
+	if g.trivias.active == nil {
+		g.trivias.active = make(map[string]*Trivia)
+	}
+	if g.trivias.active[t.Origin.ID()] != nil {
+		g.trivias.mut.Unlock()
+		return ErrTriviaRunning
+	}
+	g.trivias.active[t.Origin.ID()] = t
+	g.trivias.mut.Unlock()
+
+	rand.Shuffle(len(t.Questions), func(i, j int) { t.Questions[i], t.Questions[j] = t.Questions[j], t.Questions[i] })
+	if t.Count > 0 && t.Count < len(t.Questions) {
+		t.Questions = t.Questions[:t.Count]
+	}
+	t.winner = make(chan triviaAnswer, 1)
+	t.stop = make(chan struct{})
+	t.scores = make(map[string]*TriviaRank)
+
+	go g.runTrivia(t)
+	return nil
+}
+
+// StopTrivia stops the active game that is open to gw
+func (g *Goop) StopTrivia(gw gateway.Gateway) error {
+	var t = g.ActiveTrivia(gw)
+	if t == nil {
+		return ErrNoTrivia
+	}
+	t.Stop()
+	return nil
+}
+
+// ActiveTrivia returns the game that is open to gw
+func (g *Goop) ActiveTrivia(gw gateway.Gateway) *Trivia {
+	g.trivias.mut.Lock()
+	var active = make([]*Trivia, 0, len(g.trivias.active))
+	for _, t := range g.trivias.active {
+		active = append(active, t)
+	}
+	g.trivias.mut.Unlock()
+
+	for _, t := range active {
+		for _, a := range g.audience(t.Origin) {
+			if a == gw {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Goop) runTrivia(t *Trivia) {
+	defer func() {
+		g.trivias.mut.Lock()
+		delete(g.trivias.active, t.Origin.ID())
+		g.trivias.mut.Unlock()
+
+		g.sayTrivia(t, "Game over! "+triviaStandings(t.scores))
+	}()
+
+	var interval = t.Timeout / time.Duration(t.Hints+1)
+
+	for i := range t.Questions {
+		var q = &t.Questions[i]
+
+		g.sayTrivia(t, fmt.Sprintf("Question %d/%d: %s", i+1, len(t.Questions), q.Question))
+
+		t.mut.Lock()
+		t.current = q
+		t.mut.Unlock()
+
+		if !g.askTrivia(t, q, interval) {
+			return
+		}
+	}
+}
+
+// askTrivia waits for an answer to q, returns false if the game was stopped
+func (g *Goop) askTrivia(t *Trivia, q *TriviaQuestion, interval time.Duration) bool {
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var hints = 0
+	var win = func(w triviaAnswer) {
+		var pts = t.Hints + 1 - hints
+		g.addTriviaScore(t, w.gw, &w.user, pts)
+		g.sayTrivia(t, fmt.Sprintf("%s@%s got it: %s (+%d)", w.user.Name, w.gw.Discriminator(), q.Answers[0], pts))
+	}
+
+	for {
+		select {
+		case w := <-t.winner:
+			win(w)
+			return true
+		case <-ticker.C:
+			if hints < t.Hints {
+				hints++
+				g.sayTrivia(t, "Hint: "+Hint(q.Answers[0], hints, t.Hints+1))
+				continue
+			}
+		case <-t.stop:
+		}
+
+		t.mut.Lock()
+		t.current = nil
+		t.mut.Unlock()
+
+		// Answer may have come in just in time
+		select {
+		case w := <-t.winner:
+			win(w)
+		default:
+			g.sayTrivia(t, fmt.Sprintf("Time's up! The answer was: %s", q.Answers[0]))
+		}
+
+		select {
+		case <-t.stop:
+			return false
+		default:
+			return true
+		}
+	}
+}
+
+func triviaStandings(scores map[string]*TriviaRank) string {
+	if len(scores) == 0 {
+		return "Nobody scored"
+	}
+
+	var l = make([]*TriviaRank, 0, len(scores))
+	for _, s := range scores {
+		l = append(l, s)
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].Points > l[j].Points })
+
+	var s = make([]string, len(l))
+	for i, r := range l {
+		s[i] = fmt.Sprintf("%d. %s (%d)", i+1, r.Name, r.Points)
+	}
+	return strings.Join(s, ", ")
+}
+
+func (g *Goop) addTriviaScore(t *Trivia, gw gateway.Gateway, u *gateway.User, pts int) {
+	g.ConfigMut.Lock()
+	defer g.ConfigMut.Unlock()
+
+	// Linked accounts share their standing in the game
+	var key = g.identityKey(gw.ID(), u.ID)
+	if t.scores[key] == nil {
+		t.scores[key] = &TriviaRank{Gateway: gw.ID(), UserID: u.ID}
+	}
+	t.scores[key].Name = u.Name
+	if id := g.identity(gw.ID(), u.ID); id != "" {
+		t.scores[key].Name = id
+	}
+	t.scores[key].Points += pts
+	t.scores[key].Answers++
+
+	if g.Config == nil {
+		return
+	}
+
+	var conf = g.Config.GetTrivia()
+	if conf.Scores == nil {
+		conf.Scores = make(map[string]map[string]*TriviaScore)
+	}
+	if conf.Scores[gw.ID()] == nil {
+		conf.Scores[gw.ID()] = make(map[string]*TriviaScore)
+	}
+	if conf.Scores[gw.ID()][u.ID] == nil {
+		conf.Scores[gw.ID()][u.ID] = &TriviaScore{}
+	}
+
+	var s = conf.Scores[gw.ID()][u.ID]
+	s.Name = u.Name
+	s.Points += pts
+	s.Answers++
+}
+
+// TriviaTop returns the n highest scores, combining the scores of linked accounts
+func (g *Goop) TriviaTop(n int) []*TriviaRank {
+	var res = []*TriviaRank{}
+	var ids = map[string]*TriviaRank{}
+
+	g.ConfigMut.Lock()
+	for gid, users := range g.Config.GetTrivia().Scores {
+		for uid, s := range users {
+			if s == nil {
+				continue
+			}
+
+			var id = g.identity(gid, uid)
+			if id == "" {
+				res = append(res, &TriviaRank{
+					TriviaScore: *s,
+					Gateway:     gid,
+					UserID:      uid,
+				})
+				continue
+			}
+
+			var r = ids[id]
+			if r == nil {
+				r = &TriviaRank{TriviaScore: TriviaScore{Name: id}, Identity: id}
+				ids[id] = r
+				res = append(res, r)
+			}
+			r.Points += s.Points
+			r.Answers += s.Answers
+		}
+	}
+	g.ConfigMut.Unlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Points == res[j].Points {
+			return res[i].Answers < res[j].Answers
+		}
+		return res[i].Points > res[j].Points
+	})
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
+
+func (g *Goop) onTrivia(ev *network.Event) {
+	var msg = ev.Arg.(*gateway.Chat)
+	gw, ok := ev.Opt[0].(gateway.Gateway)
+	if !ok {
+		return
+	}
+
+	if t := g.ActiveTrivia(gw); t != nil {
+		t.Answer(gw, &msg.User, msg.Content)
+	}
+}
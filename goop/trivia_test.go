@@ -0,0 +1,71 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	var cases = []struct {
+		answer string
+		guess  string
+		match  bool
+	}{
+		{"Paris", "paris", true},
+		{"Paris", "  PARIS!", true},
+		{"Night Elves", "nightelves", true},
+		{"Lordaeron", "lordaron", true},
+		{"Lordaeron", "lord", false},
+		{"Orc", "ork", false},
+		{"Orc", "", false},
+	}
+	for _, c := range cases {
+		if goop.FuzzyMatch(c.answer, c.guess) != c.match {
+			t.Fatalf("%s/%s: expected match=%v", c.answer, c.guess, c.match)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	var cases = []struct {
+		n   int
+		res string
+	}{
+		{0, "_____ ____"},
+		{1, "Nig__ ____"},
+		{2, "Night E___"},
+	}
+	for _, c := range cases {
+		if h := goop.Hint("Night Elfs", c.n, 3); h != c.res {
+			t.Fatalf("%d: expected %q, got %q", c.n, c.res, h)
+		}
+	}
+}
+
+func TestTriviaTopIdentity(t *testing.T) {
+	var conf = &testConfig{}
+	conf.Identity.Users = map[string]map[string]string{
+		"bnet":    {"niels": "Niels"},
+		"discord": {"123": "Niels"},
+	}
+	conf.Trivia.Scores = map[string]map[string]*goop.TriviaScore{
+		"bnet":    {"niels": {Name: "niels", Points: 3, Answers: 1}, "bob": {Name: "bob", Points: 5, Answers: 2}},
+		"discord": {"123": {Name: "nielsAD", Points: 4, Answers: 2}},
+	}
+
+	var top = goop.New(conf).TriviaTop(10)
+	if len(top) != 2 {
+		t.Fatalf("Expected linked accounts to be combined, got %d entries", len(top))
+	}
+	if top[0].Identity != "Niels" || top[0].Name != "Niels" || top[0].Points != 7 || top[0].Answers != 3 || top[0].Gateway != "" {
+		t.Fatalf("Unexpected identity rank %+v", top[0])
+	}
+	if top[1].Identity != "" || top[1].Name != "bob" || top[1].Gateway != "bnet" {
+		t.Fatalf("Unexpected rank %+v", top[1])
+	}
+}
@@ -0,0 +1,132 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nielsAD/goop/gateway"
+)
+
+// Errors
+var (
+	ErrNothingToUndo = errors.New("goop: Nothing to undo")
+)
+
+// ModAction records a single moderation change, so that it can be reverted
+type ModAction struct {
+	Gateway string
+	User    gateway.User
+
+	// Previous access level, nil if unchanged
+	Prev *gateway.AccessLevel
+
+	Ban   bool
+	Unban bool
+	Kick  bool
+}
+
+// Revertible returns false if action cannot be undone (i.e. kick)
+func (a *ModAction) Revertible() bool {
+	return a.Prev != nil || a.Ban || a.Unban
+}
+
+// Maximum number of commands stored per operator
+const modHistorySize = 16
+
+type modHistory struct {
+	mut sync.Mutex
+	ops map[string][][]ModAction
+}
+
+// RecordMod stores the actions performed by a single command of op on gw
+func (g *Goop) RecordMod(gw gateway.Gateway, op *gateway.User, actions []ModAction) {
+	if len(actions) == 0 {
+		return
+	}
+
+	g.modHistory.mut.Lock()
+	defer g.modHistory.mut.Unlock()
+
+	if g.modHistory.ops == nil {
+		g.modHistory.ops = make(map[string][][]ModAction)
+	}
+
+	var key = gw.ID() + gateway.Delimiter + op.ID
+	var h = append(g.modHistory.ops[key], actions)
+	if len(h) > modHistorySize {
+		h = h[len(h)-modHistorySize:]
+	}
+	g.modHistory.ops[key] = h
+}
+
+// UndoMod reverts the actions of the last n commands of op on gw
+// Returns the reverted actions, even if an error occurred
+func (g *Goop) UndoMod(gw gateway.Gateway, op *gateway.User, n int) ([]ModAction, error) {
+	var key = gw.ID() + gateway.Delimiter + op.ID
+
+	g.modHistory.mut.Lock()
+	var h = g.modHistory.ops[key]
+	if n > len(h) {
+		n = len(h)
+	}
+	if n < 1 {
+		g.modHistory.mut.Unlock()
+		return nil, ErrNothingToUndo
+	}
+	var undo = h[len(h)-n:]
+	g.modHistory.ops[key] = h[:len(h)-n]
+	g.modHistory.mut.Unlock()
+
+	var res []ModAction
+	var err error
+	for i := len(undo) - 1; i >= 0; i-- {
+		for j := len(undo[i]) - 1; j >= 0; j-- {
+			var a = undo[i][j]
+			if !a.Revertible() {
+				continue
+			}
+
+			var target = g.Gateways()[a.Gateway]
+			if target == nil {
+				continue
+			}
+
+			if e := revertMod(target, &a); e != nil {
+				if err == nil {
+					err = e
+				}
+				continue
+			}
+			res = append(res, a)
+		}
+	}
+
+	return res, err
+}
+
+func revertMod(gw gateway.Gateway, a *ModAction) error {
+	if a.Prev != nil {
+		if _, err := gw.SetUserAccess(a.User.ID, *a.Prev); err != nil && err != gateway.ErrNotImplemented {
+			return err
+		}
+	}
+
+	var err error
+	switch {
+	case a.Ban:
+		err = gw.Unban(a.User.ID)
+	case a.Unban:
+		err = gw.Ban(a.User.ID)
+	}
+
+	switch err {
+	case nil, gateway.ErrNoUser, gateway.ErrNotImplemented, gateway.ErrNoChannel:
+		return nil
+	default:
+		return err
+	}
+}
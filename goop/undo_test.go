@@ -0,0 +1,50 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package goop_test
+
+import (
+	"testing"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/goop"
+)
+
+func TestUndoMod(t *testing.T) {
+	var op = gateway.User{ID: "op", Access: gateway.AccessOperator}
+	var bob = gateway.User{ID: "bob", Access: gateway.AccessVoice}
+
+	var g = goop.New(&testConfig{})
+	var gw1 = newTestGateway(bob)
+	var gw2 = newTestGateway()
+	if err := g.AddGateway("test"+gateway.Delimiter+"1", gw1); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddGateway("test"+gateway.Delimiter+"2", gw2); err != nil {
+		t.Fatal(err)
+	}
+
+	prev, _ := gw1.SetUserAccess(bob.ID, gateway.AccessBan)
+	g.RecordMod(gw1, &op, []goop.ModAction{{Gateway: gw1.ID(), User: bob, Prev: prev}})
+
+	for _, n := range []int{-1, 0} {
+		if _, err := g.UndoMod(gw1, &op, n); err != goop.ErrNothingToUndo {
+			t.Fatalf("UndoMod(%d): expected ErrNothingToUndo, got %v", n, err)
+		}
+	}
+	if _, err := g.UndoMod(gw2, &op, 1); err != goop.ErrNothingToUndo {
+		t.Fatalf("UndoMod(gw2): expected ErrNothingToUndo, got %v", err)
+	}
+
+	res, err := g.UndoMod(gw1, &op, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || gw1.Users()[bob.ID] != gateway.AccessVoice {
+		t.Fatalf("UndoMod: expected access to be restored, got %v", gw1.Users()[bob.ID])
+	}
+	if _, err := g.UndoMod(gw1, &op, 1); err != goop.ErrNothingToUndo {
+		t.Fatalf("UndoMod(again): expected ErrNothingToUndo, got %v", err)
+	}
+}
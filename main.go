@@ -17,10 +17,12 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"math/rand"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -47,6 +49,11 @@ var (
 	makeconf = flag.Bool("makeconf", false, "Generate a configuration file")
 )
 
+// Errors
+var (
+	ErrIncompleteGateway = errors.New("goop: Incomplete gateway configuration")
+)
+
 var logOut = log.New(color.Output, "", 0)
 var logErr = log.New(color.Error, "", 0)
 
@@ -170,74 +177,147 @@ func New(stdin io.ReadCloser, def *Config, conf *Config) (*goop.Goop, error) {
 		return nil, err
 	}
 
-	for k, g := range conf.Capi.Gateways {
-		if g.APIKey == "" {
+	for k := range conf.Capi.Gateways {
+		if _, err := addGateway(res, conf, "capi", k); err == ErrIncompleteGateway {
 			logErr.Println(color.RedString("[ERROR] Unused capi configuration '%s'", k))
-			continue
+		} else if err != nil {
+			return nil, err
 		}
+	}
 
-		gw, err := capi.New(g)
-		if err != nil {
+	for k := range conf.BNet.Gateways {
+		if _, err := addGateway(res, conf, "bnet", k); err == ErrIncompleteGateway {
+			logErr.Println(color.RedString("[ERROR] Unused bnet configuration '%s'", k))
+		} else if err != nil {
 			return nil, err
 		}
+	}
 
-		if err := res.AddGateway("capi"+gateway.Delimiter+k, gw); err != nil {
+	for k := range conf.Discord.Gateways {
+		if _, err := addGateway(res, conf, "discord", k); err == ErrIncompleteGateway {
+			logErr.Println(color.RedString("[ERROR] Unused discord configuration '%s'", k))
+		} else if err != nil {
 			return nil, err
 		}
 	}
 
-	for k, g := range conf.BNet.Gateways {
-		if g.Password == "" {
-			logErr.Println(color.RedString("[ERROR] Unused bnet configuration '%s'", k))
+	res.Factory = func(g *goop.Goop, id string) ([]string, error) {
+		var s = strings.SplitN(id, gateway.Delimiter, 2)
+		if len(s) == 2 {
+			return addGateway(g, conf, strings.ToLower(s[0]), s[1])
+		}
+		for _, sec := range []string{"capi", "bnet", "discord"} {
+			ids, err := addGateway(g, conf, sec, id)
+			if err != goop.ErrUnknownGateway {
+				return ids, err
+			}
+		}
+		return nil, goop.ErrUnknownGateway
+	}
+
+	for g1, r := range conf.Relay.To {
+		if res.Gateways()[g1] == nil {
+			logErr.Println(color.RedString("[ERROR] Unused relay configuration '%s'", g1))
 			continue
 		}
+		for g2 := range r.From {
+			if res.Gateways()[g2] == nil {
+				logErr.Println(color.RedString("[ERROR] Unused relay configuration '%s.%s'", g1, g2))
+			}
+		}
+	}
+
+	return res, nil
+}
 
-		gw, err := bnet.New(g)
+// addGateway creates gateway k from configuration section sec (capi, bnet, or discord) and adds it to g
+// Returns the IDs of all added gateways
+func addGateway(g *goop.Goop, conf *Config, sec string, k string) ([]string, error) {
+	g.ConfigMut.Lock()
+	var capiConf = conf.Capi.Gateways[k]
+	var bnetConf = conf.BNet.Gateways[k]
+	var discordConf = conf.Discord.Gateways[k]
+	g.ConfigMut.Unlock()
+
+	switch sec {
+	case "capi":
+		if capiConf == nil {
+			return nil, goop.ErrUnknownGateway
+		}
+		if capiConf.APIKey == "" {
+			return nil, ErrIncompleteGateway
+		}
+
+		gw, err := capi.New(capiConf)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := res.AddGateway("bnet"+gateway.Delimiter+k, gw); err != nil {
+		var id = "capi" + gateway.Delimiter + k
+		return []string{id}, g.AddGateway(id, gw)
+	case "bnet":
+		if bnetConf == nil {
+			return nil, goop.ErrUnknownGateway
+		}
+		if bnetConf.Password == "" {
+			return nil, ErrIncompleteGateway
+		}
+
+		gw, err := bnet.New(bnetConf)
+		if err != nil {
 			return nil, err
 		}
-	}
 
-	for k, g := range conf.Discord.Gateways {
-		if g.AuthToken == "" {
-			logErr.Println(color.RedString("[ERROR] Unused discord configuration '%s'", k))
-			continue
+		var id = "bnet" + gateway.Delimiter + k
+		return []string{id}, g.AddGateway(id, gw)
+	case "discord":
+		if discordConf == nil {
+			return nil, goop.ErrUnknownGateway
+		}
+		if discordConf.AuthToken == "" {
+			return nil, ErrIncompleteGateway
 		}
 
-		gw, err := discord.New(g)
+		gw, err := discord.New(discordConf)
 		if err != nil {
 			return nil, err
 		}
 
-		k = "discord" + gateway.Delimiter + k
-		if err := res.AddGateway(k, gw); err != nil {
+		var id = "discord" + gateway.Delimiter + k
+		if err := g.AddGateway(id, gw); err != nil {
 			return nil, err
 		}
 
+		var ids = []string{id}
 		for cid, c := range gw.Channels {
-			if err := res.AddGateway(k+gateway.Delimiter+cid, c); err != nil {
-				return nil, err
+			var cid = id + gateway.Delimiter + cid
+			if err := g.AddGateway(cid, c); err != nil {
+				return ids, err
 			}
+			ids = append(ids, cid)
 		}
+		return ids, nil
+	default:
+		return nil, goop.ErrUnknownGateway
 	}
+}
 
-	for g1, r := range conf.Relay.To {
-		if res.Gateways[g1] == nil {
-			logErr.Println(color.RedString("[ERROR] Unused relay configuration '%s'", g1))
-			continue
-		}
-		for g2 := range r.From {
-			if res.Gateways[g2] == nil {
-				logErr.Println(color.RedString("[ERROR] Unused relay configuration '%s.%s'", g1, g2))
-			}
+func setLogFlags(conf *LogConfig) {
+	var flags = 0
+	if conf.Date {
+		flags |= log.Ldate
+	}
+	if conf.Time {
+		flags |= log.Ltime
+		if conf.Microseconds {
+			flags |= log.Lmicroseconds
 		}
 	}
-
-	return res, nil
+	if conf.UTC {
+		flags |= log.LUTC
+	}
+	logOut.SetFlags(flags)
+	logErr.SetFlags(flags)
 }
 
 // Quit program
@@ -252,8 +332,24 @@ func (c *Quit) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) err
 	return nil
 }
 
+// Reload configuration
+type Reload struct {
+	cmd.Cmd
+	reload func() (*ReloadResult, error)
+}
+
+// Execute command
+func (c *Reload) Execute(t *gateway.Trigger, gw gateway.Gateway, g *goop.Goop) error {
+	res, err := c.reload()
+	if err != nil {
+		return t.Resp(err.Error())
+	}
+	return t.Resp(res.String())
+}
+
 func main() {
 	runtime.GOMAXPROCS(1)
+	rand.Seed(time.Now().UnixNano())
 	flag.Parse()
 
 	var args = flag.Args()
@@ -264,6 +360,9 @@ func main() {
 	var sig = make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 
+	var hup = make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
 	// Prevent closing stdin before restart
 	var pw io.Writer = os.Stdout
 	go func() {
@@ -284,21 +383,7 @@ start:
 		logErr.Fatalf("Error loading configuration: %v\n", err)
 	}
 
-	var flags = 0
-	if conf.Log.Date {
-		flags |= log.Ldate
-	}
-	if conf.Log.Time {
-		flags |= log.Ltime
-		if conf.Log.Microseconds {
-			flags |= log.Lmicroseconds
-		}
-	}
-	if conf.Log.UTC {
-		flags |= log.LUTC
-	}
-	logOut.SetFlags(flags)
-	logErr.SetFlags(flags)
+	setLogFlags(&conf.Log)
 
 	if *makeconf {
 		var m = conf.Map()
@@ -363,6 +448,23 @@ start:
 		cancel: func() { restart = true; cancel() },
 	})
 
+	var doReload = func() (*ReloadResult, error) {
+		res, err := reload(g, def, conf, args...)
+		if err != nil {
+			logErr.Println(color.RedString("[ERROR][CONFIG] %s", err.Error()))
+			return nil, err
+		}
+		logOut.Println(color.MagentaString("[CONFIG] %s", res.String()))
+		return res, nil
+	}
+
+	g.AddCommand("reload", &Reload{
+		Cmd:    cmd.Cmd{Priviledge: gateway.AccessOwner},
+		reload: doReload,
+	})
+
+	go watch(ctx, hup, func() { doReload() }, args...)
+
 	var done = make(chan struct{})
 	go func() {
 		for ctx.Err() == nil {
@@ -370,9 +472,11 @@ start:
 			case <-time.After(time.Minute * 3):
 			case <-ctx.Done():
 			}
+			g.ConfigMut.Lock()
 			if err := conf.Save(def); err != nil {
 				logErr.Println(color.RedString("[ERROR][CONFIG] %s", err.Error()))
 			}
+			g.ConfigMut.Unlock()
 		}
 		done <- struct{}{}
 	}()
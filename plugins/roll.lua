@@ -8,24 +8,25 @@ defoptions({
     AccessTrigger = access.Voice, -- Access level required to trigger command
 })
 
-goop:AddCommand("roll", command(function(trig, _)
+local roll_args = args("max:int=100")
+
+goop:AddCommand("roll", command(function(trig, gw)
     if trig.User.Access < options.AccessTrigger then
         return nil
     end
 
-    local max = 100
-    if #trig.Arg > 0 then
-        max = tonumber(trig.Arg[1])
-        if max == nil then
-            return trig.Resp("Cannot convert " .. trig.Arg[1] .. " to number")
-        end
+    local a, err = roll_args:Parse(trig, gw)
+    if err ~= nil then
+        return trig.Resp(err:Error())
     end
+
+    local max = a:Int("max")
     if max <= 0 then
         return trig.Resp("Pick a number above 0")
     end
     return trig.Resp(tostring(math.random(max)))
 
-end))
+end, roll_args:Usage(), "Roll a dice"))
 
 goop:AddCommand("flip", command(function(trig, _)
     if trig.User.Access < options.AccessTrigger then
@@ -37,4 +38,4 @@ goop:AddCommand("flip", command(function(trig, _)
         coin = "Tails"
     end
     return trig.Resp(coin)
-end))
+end, "", "Flip a coin"))
@@ -7,14 +7,16 @@
 defoptions({
     AccessTrigger   = access.Whitelist, -- Min access level
     DefaultLocation = "",               -- Default location
+    Cooldown        = "0s",             -- Per-user cooldown
 })
 
 local ioutil  = require("go.io")
 local strings = require("go.strings")
 local http    = require("go.http")
 local url     = require("go.url")
+local time    = require("go.time")
 
-goop:AddCommand("weather", command(function(trig)
+local weather = command(function(trig)
     if trig.User.Access < options.AccessTrigger then
         return nil
     end
@@ -41,4 +43,7 @@ goop:AddCommand("weather", command(function(trig)
     else
         return trig.Resp("Could not get weather for location (" .. resp.Status .. ")")
     end
-end))
+end, "[location]", "Print weather on location")
+
+weather.Cooldown = time.ParseDuration(options.Cooldown)
+goop:AddCommand("weather", weather)
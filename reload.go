@@ -0,0 +1,211 @@
+// Author:  Niels A.D.
+// Project: goop (https://github.com/nielsAD/goop)
+// License: Mozilla Public License, v2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nielsAD/goop/gateway"
+	"github.com/nielsAD/goop/gateway/bnet"
+	"github.com/nielsAD/goop/gateway/capi"
+	"github.com/nielsAD/goop/goop"
+	"github.com/nielsAD/goop/goop/cmd"
+	"github.com/nielsAD/gowarcraft3/network"
+)
+
+// ReloadResult lists the changes applied by reload
+type ReloadResult struct {
+	Changed []string
+
+	// Changed keys that only take effect after restarting the gateway (or goop, for plugins)
+	Restart map[string][]string
+}
+
+func (r *ReloadResult) String() string {
+	if len(r.Changed) == 0 {
+		return "Configuration reloaded, no changes"
+	}
+
+	var res = fmt.Sprintf("Configuration reloaded, %d setting(s) changed", len(r.Changed))
+
+	var ids = make([]string, 0, len(r.Restart))
+	for id := range r.Restart {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		res += fmt.Sprintf("\nRestart %s to apply [%s]", id, strings.Join(r.Restart[id], ", "))
+	}
+
+	return res
+}
+
+// restartKey returns true if changing field of a gateway in section sec requires a restart
+func restartKey(sec string, field []string) bool {
+	switch sec {
+	case "bnet":
+		var _, client = reflect.TypeOf(bnet.Config{}.Config).FieldByName(field[0])
+		return client || field[0] == "BufSize"
+	case "capi":
+		var _, client = reflect.TypeOf(capi.Config{}.Config).FieldByName(field[0])
+		return client || field[0] == "BufSize"
+	case "discord":
+		if field[0] == "AuthToken" {
+			return true
+		}
+		if field[0] != "Channels" {
+			return false
+		}
+		if len(field) < 3 {
+			// Channel added or removed
+			return true
+		}
+		switch field[2] {
+		case "ChannelID", "Webhook", "BufSize":
+			return true
+		}
+	}
+	return false
+}
+
+// reload user configuration (config.toml) and apply changes to g
+func reload(g *goop.Goop, def *Config, conf *Config, files ...string) (*ReloadResult, error) {
+	next, _, err := Load(files...)
+	if err != nil {
+		return nil, err
+	}
+
+	g.ConfigMut.Lock()
+
+	// Plugins are only loaded once, preserve their runtime defaults
+	for k, p := range def.Plugins {
+		if n := next.Plugins[k]; n != nil {
+			n.DefaultOptions = p.DefaultOptions
+		} else {
+			next.Plugins[k] = p
+		}
+	}
+
+	var aliases = make(map[string]*cmd.Alias)
+	for k, a := range conf.Commands.Alias {
+		aliases[k] = a
+	}
+
+	changed, err := conf.Reload(def, next)
+	if err != nil {
+		g.ConfigMut.Unlock()
+		return nil, err
+	}
+	*def = *next
+
+	var addAlias = make(map[string]*cmd.Alias)
+	for k, a := range conf.Commands.Alias {
+		if aliases[k] != a {
+			addAlias[k] = a
+		}
+	}
+
+	setLogFlags(&conf.Log)
+	g.ConfigMut.Unlock()
+
+	var syncCmds = len(addAlias) > 0
+	for k, a := range aliases {
+		if conf.Commands.Alias[k] != a {
+			g.RemoveCommand(k)
+			syncCmds = true
+		}
+	}
+	for k, a := range addAlias {
+		if err := g.AddCommand(k, a); err != nil {
+			g.Fire(&network.AsyncError{Src: "reload[AddCommand]", Err: err})
+		}
+	}
+	if syncCmds {
+		g.SyncCommands()
+	}
+
+	var res = ReloadResult{
+		Changed: changed,
+		Restart: make(map[string][]string),
+	}
+
+	var start = map[string]bool{}
+	var stop = map[string]bool{}
+	for _, k := range changed {
+		var s = strings.Split(k, "/")
+		if len(s) > 2 && s[0] == "Plugins" && s[2] != "Options" && s[2] != "DefaultOptions" {
+			res.Restart["goop"] = append(res.Restart["goop"], k)
+			continue
+		}
+		if len(s) < 3 || s[1] != "Gateways" {
+			continue
+		}
+
+		var sec = strings.ToLower(s[0])
+		var id = sec + gateway.Delimiter + s[2]
+		switch {
+		case len(s) == 3:
+			// Section removed
+			if g.Gateways()[id] != nil {
+				stop[id] = true
+			}
+		case g.Gateways()[id] == nil:
+			start[id] = true
+		case restartKey(sec, s[3:]):
+			res.Restart[id] = append(res.Restart[id], k)
+		}
+	}
+
+	for id := range stop {
+		if err := g.RemoveGateway(id); err != nil {
+			g.Fire(&network.AsyncError{Src: "reload[RemoveGateway]", Err: err})
+		}
+	}
+	for id := range start {
+		if _, err := g.StartGateway(id); err != nil && err != ErrIncompleteGateway {
+			g.Fire(&network.AsyncError{Src: "reload[StartGateway]", Err: err})
+		}
+	}
+
+	g.Fire(&gateway.ConfigUpdate{})
+	return &res, nil
+}
+
+func modTimes(files ...string) []time.Time {
+	var res = make([]time.Time, len(files))
+	for i, f := range files {
+		if s, err := os.Stat(f); err == nil {
+			res[i] = s.ModTime()
+		}
+	}
+	return res
+}
+
+// watch files for changes (or signal on hup) and call f, until ctx is done
+func watch(ctx context.Context, hup <-chan os.Signal, f func(), files ...string) {
+	var mod = modTimes(files...)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			mod = modTimes(files...)
+		case <-time.After(5 * time.Second):
+			var m = modTimes(files...)
+			if reflect.DeepEqual(m, mod) {
+				continue
+			}
+			mod = m
+		}
+		f()
+	}
+}
@@ -73,6 +73,15 @@ func empty(v reflect.Value) bool {
 	return false
 }
 
+// leaf returns true if struct v is treated as a single value (i.e. time.Time)
+func leaf(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct || !v.CanInterface() {
+		return false
+	}
+	_, ok := v.Interface().(encoding.TextMarshaler)
+	return ok
+}
+
 // MergeOptions for Merge()
 type MergeOptions struct {
 	Overwrite bool
@@ -267,6 +276,13 @@ func merge(dst reflect.Value, src reflect.Value, opt *MergeOptions) ([]string, e
 
 		return undecoded, nil
 	case reflect.Struct:
+		if leaf(src) {
+			if dst.IsZero() || opt.Overwrite {
+				return nil, Assign(dst, src)
+			}
+			return nil, nil
+		}
+
 		var undecoded = []string{}
 		for i := 0; i < src.NumField(); i++ {
 			var f = src.Field(i)
@@ -335,6 +351,10 @@ func Map(val interface{}) interface{} {
 		}
 		return m
 	case reflect.Struct:
+		if leaf(v) {
+			return v.Interface()
+		}
+
 		var m = make(map[string]interface{})
 		for i := 0; i < v.NumField(); i++ {
 			var f = v.Field(i)
@@ -396,6 +416,10 @@ func flatMap(prf string, val reflect.Value, dst map[string]interface{}) {
 			flatMap(pre, val.MapIndex(key), dst)
 		}
 	case reflect.Struct:
+		if leaf(val) {
+			dst[prf] = val.Interface()
+			return
+		}
 		for i := 0; i < val.NumField(); i++ {
 			var f = val.Field(i)
 			if !f.CanInterface() {
@@ -597,6 +621,39 @@ func Get(src interface{}, key string) (interface{}, error) {
 	return val.Interface(), nil
 }
 
+// create allocates a new entry for key if its parent is a map of pointers (i.e. map[string]*Config)
+func create(dst interface{}, key string) *reflect.Value {
+	parent, k := ParentKey(key)
+	if parent == "" || k == "" || k == "[]" {
+		return nil
+	}
+
+	p, _ := Find(dst, parent)
+	if p == nil {
+		p = create(dst, parent)
+	}
+	if p == nil || p.Kind() != reflect.Map || p.Type().Elem().Kind() != reflect.Ptr {
+		return nil
+	}
+	if p.IsNil() {
+		if !p.CanSet() {
+			return nil
+		}
+		p.Set(reflect.MakeMap(p.Type()))
+	}
+
+	var idx = reflect.New(p.Type().Key()).Elem()
+	if err := AssignString(idx, k); err != nil {
+		return nil
+	}
+
+	var val = reflect.New(p.Type().Elem().Elem())
+	p.SetMapIndex(idx, val)
+
+	var res = val.Elem()
+	return &res
+}
+
 // Set config value via flat index string
 func Set(dst interface{}, key string, val interface{}) error {
 	f, key := Find(dst, key)
@@ -610,6 +667,14 @@ func Set(dst interface{}, key string, val interface{}) error {
 	}
 
 	p, _ := Find(dst, parent)
+	if p == nil && create(dst, parent) != nil {
+		// Retry with newly created parent, undo on failure
+		if err := Set(dst, parent+"/"+key, val); err != nil {
+			Unset(dst, parent)
+			return err
+		}
+		return nil
+	}
 	if p == nil {
 		return ErrUnknownKey
 	}
@@ -712,6 +777,14 @@ func SetString(dst interface{}, key string, val string) error {
 	}
 
 	p, _ := Find(dst, parent)
+	if p == nil && create(dst, parent) != nil {
+		// Retry with newly created parent, undo on failure
+		if err := SetString(dst, key, val); err != nil {
+			Unset(dst, parent)
+			return err
+		}
+		return nil
+	}
 	if p == nil {
 		return ErrUnknownKey
 	}